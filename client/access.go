@@ -0,0 +1,35 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+var accessLevels = map[string]gitlab.AccessLevelValue{
+	"none":       gitlab.NoPermissions,
+	"guest":      gitlab.GuestPermissions,
+	"reporter":   gitlab.ReporterPermissions,
+	"developer":  gitlab.DeveloperPermissions,
+	"maintainer": gitlab.MaintainerPermissions,
+	"owner":      gitlab.OwnerPermissions,
+}
+
+// AccessLevelName returns the name of the given access level
+func AccessLevelName(level gitlab.AccessLevelValue) string {
+	for name, value := range accessLevels {
+		if value == level {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", level)
+}
+
+// ParseAccessLevel returns the access level with the given name, e.g. "developer"
+func ParseAccessLevel(name string) (gitlab.AccessLevelValue, error) {
+	if level, ok := accessLevels[strings.ToLower(name)]; ok {
+		return level, nil
+	}
+	return 0, fmt.Errorf("invalid access level %q: must be one of none, guest, reporter, developer, maintainer or owner", name)
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListAllBranches returns all the branches of a project walking every result page
+func (client *Client) ListAllBranches(pid string) ([]*gitlab.Branch, error) {
+
+	var branches []*gitlab.Branch
+	opt := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{PerPage: pageSize}}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Branches.ListBranches(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+		branches = append(branches, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return branches, nil
+}
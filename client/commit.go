@@ -0,0 +1,97 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// AuthorSearchLimit the maximum number of commits scanned by ListCommitsByAuthor
+// when the commits are not limited by date
+const AuthorSearchLimit = 1000
+
+// ListCommitsByAuthor returns up to limit commits of a project whose author name contains
+// or whose author email equals the given author. The pages are walked until the limit is
+// reached since the commits API does not filter by author. Without the Since option only
+// the latest AuthorSearchLimit commits are scanned.
+func (client *Client) ListCommitsByAuthor(pid string, opt *gitlab.ListCommitsOptions, author string, limit int) ([]*gitlab.Commit, error) {
+
+	var commits []*gitlab.Commit
+	if opt.PerPage == 0 {
+		opt.PerPage = pageSize
+	}
+
+	scanned := 0
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Commits.ListCommits(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, commit := range p {
+			if strings.Contains(strings.ToLower(commit.AuthorName), strings.ToLower(author)) ||
+				strings.EqualFold(commit.AuthorEmail, author) {
+				commits = append(commits, commit)
+				if len(commits) == limit {
+					return 0, nil
+				}
+			}
+		}
+
+		scanned += len(p)
+		if opt.Since == nil && scanned >= AuthorSearchLimit {
+			return 0, nil
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+// ListAllCommitDiffs returns the diffs of all the files changed by a commit walking every result page
+func (client *Client) ListAllCommitDiffs(pid, sha string) ([]*gitlab.Diff, error) {
+
+	var diffs []*gitlab.Diff
+	opt := &gitlab.GetCommitDiffOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Commits.GetCommitDiff(pid, sha, opt)
+		if err != nil {
+			return 0, err
+		}
+		diffs = append(diffs, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diffs, nil
+}
+
+// ListAllCommitStatuses returns the statuses of a commit walking every result page
+func (client *Client) ListAllCommitStatuses(pid, sha string, opt *gitlab.GetCommitStatusesOptions) ([]*gitlab.CommitStatus, error) {
+
+	var statuses []*gitlab.CommitStatus
+	opt.PerPage = pageSize
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Commits.GetCommitStatuses(pid, sha, opt)
+		if err != nil {
+			return 0, err
+		}
+		statuses = append(statuses, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListAllEnvironments returns all the environments of a project walking every result page
+func (client *Client) ListAllEnvironments(pid string) ([]*gitlab.Environment, error) {
+
+	var environments []*gitlab.Environment
+	opt := &gitlab.ListEnvironmentsOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Environments.ListEnvironments(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+		environments = append(environments, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return environments, nil
+}
+
+// ResolveEnvironment returns the environment of the project with the given name or ID
+func (client *Client) ResolveEnvironment(pid, environment string) (*gitlab.Environment, error) {
+
+	if environmentID, err := strconv.Atoi(environment); err == nil {
+		env, _, err := client.Environments.GetEnvironment(pid, environmentID)
+		return env, err
+	}
+
+	environments, err := client.ListAllEnvironments(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, env := range environments {
+		if env.Name == environment {
+			return env, nil
+		}
+	}
+
+	return nil, fmt.Errorf("environment %q not found", environment)
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListAllSubgroups returns all the direct subgroups of a group walking every result page
+func (client *Client) ListAllSubgroups(gid string) ([]*gitlab.Group, error) {
+
+	var groups []*gitlab.Group
+	opt := &gitlab.ListSubgroupsOptions{ListOptions: gitlab.ListOptions{PerPage: pageSize}}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Groups.ListSubgroups(gid, opt)
+		if err != nil {
+			return 0, err
+		}
+		groups = append(groups, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
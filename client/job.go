@@ -0,0 +1,211 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/xanzy/go-gitlab"
+)
+
+const (
+	// LatestPipeline identifies the most recent pipeline of a ref
+	LatestPipeline = "latest"
+)
+
+// ResolvePipelineID returns the id of the given pipeline. The pipeline can be
+// either a numeric id or "latest", in which case the most recent pipeline of
+// the given ref is returned.
+func (client *Client) ResolvePipelineID(pid, pipeline, ref string) (int, error) {
+
+	if pipeline != LatestPipeline {
+		pipelineID, err := strconv.Atoi(pipeline)
+		if err != nil {
+			return 0, fmt.Errorf("invalid pipeline %q: must be an id or %q", pipeline, LatestPipeline)
+		}
+		return pipelineID, nil
+	}
+
+	opt := &gitlab.ListProjectPipelinesOptions{
+		OrderBy:     gitlab.String("id"),
+		Sort:        gitlab.String("desc"),
+		ListOptions: gitlab.ListOptions{Page: 1, PerPage: 1},
+	}
+	if ref != "" {
+		opt.Ref = gitlab.String(ref)
+	}
+
+	pipelines, _, err := client.Pipelines.ListProjectPipelines(pid, opt)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(pipelines) == 0 {
+		return 0, errors.New("no pipeline found")
+	}
+
+	return pipelines[0].ID, nil
+}
+
+// ListAllPipelineJobs returns all the jobs of a pipeline walking every result page
+func (client *Client) ListAllPipelineJobs(pid string, pipelineID int) ([]*gitlab.Job, error) {
+
+	var jobs []*gitlab.Job
+	opt := &gitlab.ListJobsOptions{ListOptions: gitlab.ListOptions{PerPage: pageSize}}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Jobs.ListPipelineJobs(pid, pipelineID, opt)
+		if err != nil {
+			return 0, err
+		}
+		jobs = append(jobs, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// FindPipelineJobs returns the jobs of a pipeline whose name matches the given pattern.
+// The pattern supports the glob syntax of util.CompileGlob, e.g. "test:*".
+func (client *Client) FindPipelineJobs(pid string, pipelineID int, pattern string) ([]*gitlab.Job, error) {
+
+	re, err := util.CompileGlob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job name pattern %q: %v", pattern, err)
+	}
+
+	jobs, err := client.ListAllPipelineJobs(pid, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterJobsByName(jobs, re), nil
+}
+
+// filterJobsByName returns the jobs whose name matches the given pattern
+func filterJobsByName(jobs []*gitlab.Job, pattern *regexp.Regexp) []*gitlab.Job {
+	var matches []*gitlab.Job
+	for _, job := range jobs {
+		if pattern.MatchString(job.Name) {
+			matches = append(matches, job)
+		}
+	}
+	return matches
+}
+
+// Job rapresents a CI/CD job. TagList and FailureReason are read from the raw
+// response since the go-gitlab Job lacks them.
+type Job struct {
+	gitlab.Job
+	TagList       []string `json:"tag_list"`
+	FailureReason string   `json:"failure_reason"`
+}
+
+// GetJob returns a job of a project
+func (client *Client) GetJob(pid string, jobID int) (*Job, error) {
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("projects/%s/jobs/%d", pathEscape(pid), jobID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	job := new(Job)
+	if _, err := client.Do(req, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// JobVariable rapresents a variable passed to a manual job
+type JobVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type playJobOptions struct {
+	JobVariablesAttributes []*JobVariable `json:"job_variables_attributes,omitempty"`
+}
+
+// PlayJobWithVariables triggers a manual job passing the given variables
+func (client *Client) PlayJobWithVariables(pid string, jobID int, variables []*JobVariable) (*gitlab.Job, error) {
+
+	u := fmt.Sprintf("projects/%s/jobs/%d/play", pathEscape(pid), jobID)
+	opt := &playJobOptions{JobVariablesAttributes: variables}
+
+	req, err := client.NewRequest("POST", u, opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	job := new(gitlab.Job)
+	if _, err := client.Do(req, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// IsJobFinished returns true if the job reached a final status
+func IsJobFinished(job *gitlab.Job) bool {
+	switch job.Status {
+	case "created", "pending", "running", "preparing", "waiting_for_resource":
+		return false
+	}
+	return true
+}
+
+// ListAllProjectJobs returns all the jobs of a project walking every result page
+func (client *Client) ListAllProjectJobs(pid string) ([]gitlab.Job, error) {
+
+	var jobs []gitlab.Job
+	opt := &gitlab.ListJobsOptions{ListOptions: gitlab.ListOptions{PerPage: pageSize}}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Jobs.ListProjectJobs(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+		jobs = append(jobs, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// DeleteJobArtifacts deletes the artifacts of a job
+func (client *Client) DeleteJobArtifacts(pid string, jobID int) error {
+
+	u := fmt.Sprintf("projects/%s/jobs/%d/artifacts", pathEscape(pid), jobID)
+
+	req, err := client.NewRequest("DELETE", u, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(req, nil)
+	return err
+}
+
+// GetJobArtifactsSize returns the size in bytes of the artifacts of a job, trace excluded
+func GetJobArtifactsSize(job *gitlab.Job) int64 {
+	size := int64(0)
+	for _, artifact := range job.Artifacts {
+		if artifact.FileType != "trace" {
+			size += int64(artifact.Size)
+		}
+	}
+	if size == 0 {
+		size = int64(job.ArtifactsFile.Size)
+	}
+	return size
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestFilterJobsByName(t *testing.T) {
+	var jobs []*gitlab.Job
+	for _, name := range []string{"build", "rspec 1/3", "rspec 2/3", "rspec 3/3", "test:unit", "test:e2e", "deploy"} {
+		jobs = append(jobs, &gitlab.Job{Name: name})
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"*", []string{"build", "rspec 1/3", "rspec 2/3", "rspec 3/3", "test:unit", "test:e2e", "deploy"}},
+		{"rspec*", []string{"rspec 1/3", "rspec 2/3", "rspec 3/3"}},
+		{"rspec 2/3", []string{"rspec 2/3"}},
+		{"test:*", []string{"test:unit", "test:e2e"}},
+		{"deploy", []string{"deploy"}},
+		{"lint", nil},
+	}
+
+	for _, tt := range tests {
+		pattern, err := util.CompileGlob(tt.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, job := range filterJobsByName(jobs, pattern) {
+			got = append(got, job.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("filterJobsByName(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Label rapresents a project label. The priority is nil when the label is not
+// prioritized, which the go-gitlab Label cannot tell apart from the priority 0.
+type Label struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+	Priority    *int   `json:"priority"`
+}
+
+// SamePriority returns true if the labels are both unprioritized or have the same priority
+func (label *Label) SamePriority(other *Label) bool {
+	if label.Priority == nil || other.Priority == nil {
+		return label.Priority == other.Priority
+	}
+	return *label.Priority == *other.Priority
+}
+
+// listLabelsOptions rapresents the options to list the labels of a project
+type listLabelsOptions struct {
+	gitlab.ListOptions
+	IncludeAncestorGroups *bool `url:"include_ancestor_groups,omitempty" json:"include_ancestor_groups,omitempty"`
+}
+
+// ListAllLabels returns all the labels of a project walking every result page.
+// The labels inherited from the ancestor groups are excluded.
+func (client *Client) ListAllLabels(pid string) ([]*Label, error) {
+
+	var labels []*Label
+	opt := &listLabelsOptions{
+		ListOptions:           gitlab.ListOptions{PerPage: pageSize},
+		IncludeAncestorGroups: gitlab.Bool(false),
+	}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		req, err := client.NewRequest("GET", labelsURL(pid), opt, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var p []*Label
+		resp, err := client.Do(req, &p)
+		if err != nil {
+			return 0, err
+		}
+		labels = append(labels, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// GetLabel returns the project label with the given name
+func (client *Client) GetLabel(pid, name string) (*Label, error) {
+
+	labels, err := client.ListAllLabels(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, label := range labels {
+		if label.Name == name {
+			return label, nil
+		}
+	}
+
+	return nil, fmt.Errorf("label %q not found", name)
+}
+
+// SaveLabel creates a label in a project or, if name is not empty, updates the
+// project label with the given name, renaming it when the label name differs.
+// A nil priority removes the label priority.
+func (client *Client) SaveLabel(pid, name string, label *Label) error {
+
+	// the priority is not omitted when nil, so that it is sent as null
+	opt := &struct {
+		Name        string `json:"name"`
+		NewName     string `json:"new_name,omitempty"`
+		Color       string `json:"color"`
+		Description string `json:"description"`
+		Priority    *int   `json:"priority"`
+	}{label.Name, "", label.Color, label.Description, label.Priority}
+
+	method := "POST"
+	if name != "" {
+		method = "PUT"
+		opt.Name = name
+		if label.Name != name {
+			opt.NewName = label.Name
+		}
+	}
+
+	req, err := client.NewRequest(method, labelsURL(pid), opt, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(req, nil)
+	return err
+}
+
+func labelsURL(pid string) string {
+	return fmt.Sprintf("projects/%s/labels", pathEscape(pid))
+}
+
+// ExpandProjects returns the paths of the projects matching the given spec.
+// The spec is either a project path or ID, or a group path followed by "/*"
+// to select all the projects of the group and its subgroups.
+func (client *Client) ExpandProjects(spec string) ([]string, error) {
+
+	if !strings.HasSuffix(spec, "/*") {
+		return []string{spec}, nil
+	}
+
+	projects, err := client.ListAllGroupProjects(strings.TrimSuffix(spec, "/*"), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, project := range projects {
+		paths = append(paths, project.PathWithNamespace)
+	}
+	return paths, nil
+}
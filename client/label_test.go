@@ -0,0 +1,31 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestLabelSamePriority(t *testing.T) {
+	priority := func(p int) *int {
+		return &p
+	}
+
+	tests := []struct {
+		a, b *int
+		want bool
+	}{
+		{nil, nil, true},
+		{priority(0), nil, false},
+		{nil, priority(0), false},
+		{priority(0), priority(0), true},
+		{priority(1), priority(2), false},
+		{priority(3), priority(3), true},
+	}
+
+	for _, tt := range tests {
+		a := &Label{Name: "bug", Priority: tt.a}
+		b := &Label{Name: "bug", Priority: tt.b}
+		if got := a.SamePriority(b); got != tt.want {
+			t.Errorf("SamePriority(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"github.com/xanzy/go-gitlab"
+)
+
+// Member rapresents a project or group member
+type Member struct {
+	ID          int                     `json:"id"`
+	Username    string                  `json:"username"`
+	Name        string                  `json:"name"`
+	State       string                  `json:"state"`
+	AccessLevel gitlab.AccessLevelValue `json:"access_level"`
+	ExpiresAt   *gitlab.ISOTime         `json:"expires_at"`
+}
+
+// Expiry returns the membership expiry date in the YYYY-MM-DD format, or an empty string if it never expires
+func (member *Member) Expiry() string {
+	if member.ExpiresAt == nil {
+		return ""
+	}
+	return member.ExpiresAt.String()
+}
+
+// ListMembers returns the direct members of a project or a group walking every result page
+func (client *Client) ListMembers(owner *Owner) ([]*Member, error) {
+
+	var members []*Member
+	listOptions := gitlab.ListOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		listOptions.Page = page
+
+		if owner.Group != "" {
+			p, resp, err := client.Groups.ListGroupMembers(owner.Group, &gitlab.ListGroupMembersOptions{ListOptions: listOptions})
+			if err != nil {
+				return 0, err
+			}
+			for _, m := range p {
+				members = append(members, &Member{m.ID, m.Username, m.Name, m.State, m.AccessLevel, m.ExpiresAt})
+			}
+			return resp.NextPage, nil
+		}
+
+		p, resp, err := client.ProjectMembers.ListProjectMembers(owner.Project, &gitlab.ListProjectMembersOptions{ListOptions: listOptions})
+		if err != nil {
+			return 0, err
+		}
+		for _, m := range p {
+			members = append(members, &Member{m.ID, m.Username, m.Name, m.State, m.AccessLevel, m.ExpiresAt})
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddMember adds a user to a project or a group. The expiry date, in the
+// YYYY-MM-DD format, is optional.
+func (client *Client) AddMember(owner *Owner, userID int, accessLevel gitlab.AccessLevelValue, expiresAt string) error {
+
+	var expiry *string
+	if expiresAt != "" {
+		expiry = gitlab.String(expiresAt)
+	}
+
+	var err error
+	if owner.Group != "" {
+		_, _, err = client.GroupMembers.AddGroupMember(owner.Group, &gitlab.AddGroupMemberOptions{
+			UserID:      gitlab.Int(userID),
+			AccessLevel: gitlab.AccessLevel(accessLevel),
+			ExpiresAt:   expiry,
+		})
+	} else {
+		_, _, err = client.ProjectMembers.AddProjectMember(owner.Project, &gitlab.AddProjectMemberOptions{
+			UserID:      gitlab.Int(userID),
+			AccessLevel: gitlab.AccessLevel(accessLevel),
+			ExpiresAt:   expiry,
+		})
+	}
+	return err
+}
+
+// UpdateMember updates the access level and the expiry date of a project or group member.
+// A nil expiry date is left unchanged, an empty one is removed.
+func (client *Client) UpdateMember(owner *Owner, userID int, accessLevel gitlab.AccessLevelValue, expiry *string) error {
+
+	var err error
+	if owner.Group != "" {
+		_, _, err = client.GroupMembers.EditGroupMember(owner.Group, userID, &gitlab.EditGroupMemberOptions{
+			AccessLevel: gitlab.AccessLevel(accessLevel),
+			ExpiresAt:   expiry,
+		})
+	} else {
+		_, _, err = client.ProjectMembers.EditProjectMember(owner.Project, userID, &gitlab.EditProjectMemberOptions{
+			AccessLevel: gitlab.AccessLevel(accessLevel),
+			ExpiresAt:   expiry,
+		})
+	}
+	return err
+}
+
+// RemoveMember removes a user from a project or a group
+func (client *Client) RemoveMember(owner *Owner, userID int) error {
+
+	var err error
+	if owner.Group != "" {
+		_, err = client.GroupMembers.RemoveGroupMember(owner.Group, userID)
+	} else {
+		_, err = client.ProjectMembers.DeleteProjectMember(owner.Project, userID)
+	}
+	return err
+}
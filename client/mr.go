@@ -0,0 +1,35 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// listMergeRequestsOptions adds the reviewer filter to the list options
+type listMergeRequestsOptions struct {
+	gitlab.ListProjectMergeRequestsOptions
+	ReviewerUsername *string `url:"reviewer_username,omitempty"`
+}
+
+// ListProjectMergeRequests returns the merge requests of a project,
+// optionally filtered by reviewer username
+func (client *Client) ListProjectMergeRequests(pid string, opt *gitlab.ListProjectMergeRequestsOptions, reviewer string) ([]*gitlab.MergeRequest, error) {
+
+	if reviewer == "" {
+		mergeRequests, _, err := client.MergeRequests.ListProjectMergeRequests(pid, opt)
+		return mergeRequests, err
+	}
+
+	u := fmt.Sprintf("projects/%s/merge_requests", pathEscape(pid))
+	req, err := client.NewRequest("GET", u, &listMergeRequestsOptions{*opt, gitlab.String(reviewer)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mergeRequests []*gitlab.MergeRequest
+	if _, err := client.Do(req, &mergeRequests); err != nil {
+		return nil, err
+	}
+	return mergeRequests, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Owner identifies a project or a group owning resources such as CI/CD variables or members
+type Owner struct {
+	Project string
+	Group   string
+}
+
+// Validate returns an error unless exactly one between project and group is set
+func (owner *Owner) Validate() error {
+	if (owner.Project == "") == (owner.Group == "") {
+		return errors.New("requires either a project or a group")
+	}
+	return nil
+}
+
+// String returns the project or the group path
+func (owner *Owner) String() string {
+	if owner.Group != "" {
+		return owner.Group
+	}
+	return owner.Project
+}
+
+// ResolveOwner returns the owner identified by the given path, which can be
+// either a project or a group
+func (client *Client) ResolveOwner(path string) (*Owner, error) {
+
+	_, resp, err := client.Projects.GetProject(path, nil)
+	if err == nil {
+		return &Owner{Project: path}, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+
+	_, _, err = client.Groups.GetGroup(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Owner{Group: path}, nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Package rapresents a package of the project package registry.
+// The packages API is requested by hand since the go-gitlab version in use lacks it.
+type Package struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Version     string     `json:"version"`
+	PackageType string     `json:"package_type"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+// listProjectPackagesOptions rapresents the options to list the packages of a project
+type listProjectPackagesOptions struct {
+	gitlab.ListOptions
+	PackageType *string `url:"package_type,omitempty" json:"package_type,omitempty"`
+}
+
+// ListAllProjectPackages returns all the packages of a project, optionally
+// filtered by package type, walking every result page
+func (client *Client) ListAllProjectPackages(pid, packageType string) ([]*Package, error) {
+
+	var packages []*Package
+	opt := &listProjectPackagesOptions{ListOptions: gitlab.ListOptions{PerPage: pageSize}}
+	if packageType != "" {
+		opt.PackageType = gitlab.String(packageType)
+	}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		req, err := client.NewRequest("GET", fmt.Sprintf("projects/%s/packages", pathEscape(pid)), opt, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var p []*Package
+		resp, err := client.Do(req, &p)
+		if err != nil {
+			return 0, err
+		}
+		packages = append(packages, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return packages, nil
+}
+
+// DeleteProjectPackage deletes a package of a project
+func (client *Client) DeleteProjectPackage(pid string, packageID int) error {
+
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("projects/%s/packages/%d", pathEscape(pid), packageID), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(req, nil)
+	return err
+}
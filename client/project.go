@@ -0,0 +1,98 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ResolveProject returns the project identified by the given ID or full path.
+// When no project matches exactly, the projects the user is member of whose
+// name matches are listed as candidates in the error.
+func (client *Client) ResolveProject(name string) (*gitlab.Project, error) {
+
+	project, resp, err := client.Projects.GetProject(name, nil)
+	if err == nil {
+		return project, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+
+	opt := &gitlab.ListProjectsOptions{
+		Membership:  gitlab.Bool(true),
+		Search:      gitlab.String(name),
+		ListOptions: gitlab.ListOptions{Page: 1, PerPage: 20},
+	}
+	projects, _, err := client.Projects.ListProjects(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("project %q not found", name)
+	}
+
+	var candidates []string
+	for _, p := range projects {
+		candidates = append(candidates, p.PathWithNamespace)
+	}
+	return nil, fmt.Errorf("project %q not found, use the full path of one of: %s", name, strings.Join(candidates, ", "))
+}
+
+// listProjectsOptions adds the last activity filter to the list options
+type listProjectsOptions struct {
+	gitlab.ListProjectsOptions
+	LastActivityAfter *time.Time `url:"last_activity_after,omitempty" json:"last_activity_after,omitempty"`
+}
+
+// ListProjects returns the projects visible to the user, optionally limited
+// to the projects active after the given time
+func (client *Client) ListProjects(opt *gitlab.ListProjectsOptions, lastActivityAfter time.Time) ([]*gitlab.Project, error) {
+
+	if lastActivityAfter.IsZero() {
+		projects, _, err := client.Projects.ListProjects(opt)
+		return projects, err
+	}
+
+	req, err := client.NewRequest("GET", "projects", &listProjectsOptions{*opt, &lastActivityAfter}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*gitlab.Project
+	if _, err := client.Do(req, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ListAllGroupProjects returns all the projects of a group walking every result page.
+// The projects shared with the group from other namespaces are excluded.
+func (client *Client) ListAllGroupProjects(gid string, includeSubgroups bool) ([]*gitlab.Project, error) {
+
+	var projects []*gitlab.Project
+	opt := &gitlab.ListGroupProjectsOptions{
+		IncludeSubgroups: gitlab.Bool(includeSubgroups),
+		WithShared:       gitlab.Bool(false),
+		ListOptions:      gitlab.ListOptions{PerPage: pageSize},
+	}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.Groups.ListGroupProjects(gid, opt)
+		if err != nil {
+			return 0, err
+		}
+		projects = append(projects, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/xanzy/go-gitlab"
+)
+
+// registryWorkers the number of concurrent requests used to fetch the tag details
+const registryWorkers = 8
+
+// ListAllRegistryRepositories returns all the container registry repositories of a project walking every result page
+func (client *Client) ListAllRegistryRepositories(pid string) ([]*gitlab.RegistryRepository, error) {
+
+	var repositories []*gitlab.RegistryRepository
+	opt := &gitlab.ListRegistryRepositoriesOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.ContainerRegistry.ListRegistryRepositories(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+		repositories = append(repositories, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories, nil
+}
+
+// ResolveRegistryRepository returns the container registry repository of the project with the given ID, path or name
+func (client *Client) ResolveRegistryRepository(pid, repository string) (*gitlab.RegistryRepository, error) {
+
+	repositories, err := client.ListAllRegistryRepositories(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryID, _ := strconv.Atoi(repository)
+	for _, repo := range repositories {
+		if repo.ID == repositoryID || repo.Path == repository || repo.Name == repository {
+			return repo, nil
+		}
+	}
+
+	return nil, fmt.Errorf("registry repository %q not found", repository)
+}
+
+// ListRegistryTagDetails returns the tags of a container registry repository
+// with their details, such as size and creation date, sorted from the newest
+func (client *Client) ListRegistryTagDetails(pid string, repositoryID int) ([]*gitlab.RegistryRepositoryTag, error) {
+
+	var tags []*gitlab.RegistryRepositoryTag
+	opt := &gitlab.ListRegistryRepositoryTagsOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := client.ContainerRegistry.ListRegistryRepositoryTags(pid, repositoryID, opt)
+		if err != nil {
+			return 0, err
+		}
+		tags = append(tags, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*gitlab.RegistryRepositoryTag, len(tags))
+	errs := make([]error, len(tags))
+	util.ForEach(len(tags), registryWorkers, func(i int) {
+		details[i], _, errs[i] = client.ContainerRegistry.GetRegistryRepositoryTagDetail(pid, repositoryID, tags[i].Name)
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(details, func(i, j int) bool {
+		return tagCreatedAt(details[i]).After(tagCreatedAt(details[j]))
+	})
+
+	return details, nil
+}
+
+// SelectRegistryTagsToDelete returns the tags matching the given pattern that are
+// older than the given time, skipping the newest keep matching tags.
+// The tags must be sorted from the newest.
+func SelectRegistryTagsToDelete(tags []*gitlab.RegistryRepositoryTag, pattern *regexp.Regexp, keep int, olderThan time.Time) []*gitlab.RegistryRepositoryTag {
+
+	var selected []*gitlab.RegistryRepositoryTag
+	matched := 0
+	for _, tag := range tags {
+		if pattern != nil && !pattern.MatchString(tag.Name) {
+			continue
+		}
+
+		matched++
+		if matched <= keep || tag.CreatedAt == nil || tag.CreatedAt.After(olderThan) {
+			continue
+		}
+		selected = append(selected, tag)
+	}
+
+	return selected
+}
+
+func tagCreatedAt(tag *gitlab.RegistryRepositoryTag) time.Time {
+	if tag.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *tag.CreatedAt
+}
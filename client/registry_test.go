@@ -0,0 +1,53 @@
+package client
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestSelectRegistryTagsToDelete(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) *time.Time {
+		t := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &t
+	}
+
+	// sorted from the newest, as returned by ListRegistryTagDetails
+	tags := []*gitlab.RegistryRepositoryTag{
+		{Name: "latest", CreatedAt: daysAgo(1)},
+		{Name: "v3", CreatedAt: daysAgo(2)},
+		{Name: "feature-x", CreatedAt: daysAgo(10)},
+		{Name: "v2", CreatedAt: daysAgo(40)},
+		{Name: "v1", CreatedAt: daysAgo(60)},
+		{Name: "unknown"},
+	}
+
+	tests := []struct {
+		name      string
+		pattern   *regexp.Regexp
+		keep      int
+		olderThan time.Time
+		want      []string
+	}{
+		{"all older than 30 days", nil, 0, *daysAgo(30), []string{"v2", "v1"}},
+		{"keep the newest", nil, 4, *daysAgo(30), []string{"v1"}},
+		{"keep more than the tags", nil, 10, *daysAgo(0), nil},
+		{"pattern", regexp.MustCompile(`^v\d+$`), 1, *daysAgo(0), []string{"v2", "v1"}},
+		{"pattern and age", regexp.MustCompile(`^v\d+$`), 0, *daysAgo(50), []string{"v1"}},
+		{"no match", regexp.MustCompile(`^release-.*$`), 0, *daysAgo(0), nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, tag := range SelectRegistryTagsToDelete(tags, tt.pattern, tt.keep, tt.olderThan) {
+			got = append(got, tag.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: SelectRegistryTagsToDelete() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
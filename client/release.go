@@ -0,0 +1,134 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// GetPreviousTag returns the tag closest to the given ref in its history, other
+// than the given tag, or nil if there is none. As git describe does, the commits
+// of the ref are walked from the newest until a tagged one is found.
+func (client *Client) GetPreviousTag(pid, tagName, ref string) (*gitlab.Tag, error) {
+
+	tags := make(map[string]*gitlab.Tag)
+	tagsOpt := &gitlab.ListTagsOptions{ListOptions: gitlab.ListOptions{PerPage: pageSize}}
+	err := WalkPages(func(page int) (int, error) {
+		tagsOpt.Page = page
+		p, resp, err := client.Tags.ListTags(pid, tagsOpt)
+		if err != nil {
+			return 0, err
+		}
+		for _, tag := range p {
+			if tag.Name != tagName && tag.Commit != nil && tags[tag.Commit.ID] == nil {
+				tags[tag.Commit.ID] = tag
+			}
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+
+	var previousTag *gitlab.Tag
+	commitsOpt := &gitlab.ListCommitsOptions{
+		RefName:     gitlab.String(ref),
+		ListOptions: gitlab.ListOptions{PerPage: pageSize},
+	}
+	err = WalkPages(func(page int) (int, error) {
+		commitsOpt.Page = page
+		p, resp, err := client.Commits.ListCommits(pid, commitsOpt)
+		if err != nil {
+			return 0, err
+		}
+		for _, commit := range p {
+			if tag := tags[commit.ID]; tag != nil {
+				previousTag = tag
+				return 0, nil
+			}
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return previousTag, nil
+}
+
+// GenerateReleaseNotes returns the release notes listing the merge requests
+// merged into the target branch since the previous tag, or since the beginning
+// if the previous tag is nil
+func (client *Client) GenerateReleaseNotes(pid string, previousTag *gitlab.Tag, targetBranch string) (string, error) {
+
+	var since time.Time
+	if previousTag != nil && previousTag.Commit != nil && previousTag.Commit.CommittedDate != nil {
+		since = *previousTag.Commit.CommittedDate
+	}
+
+	// the merge requests are walked from the last updated, since a merge
+	// request cannot be updated before being merged
+	opt := &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.String("merged"),
+		TargetBranch: gitlab.String(targetBranch),
+		OrderBy:      gitlab.String("updated_at"),
+		Sort:         gitlab.String("desc"),
+		ListOptions:  gitlab.ListOptions{PerPage: pageSize},
+	}
+
+	var notes bytes.Buffer
+	if previousTag != nil {
+		fmt.Fprintf(&notes, "## Changes since %s\n\n", previousTag.Name)
+	} else {
+		notes.WriteString("## Changes\n\n")
+	}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		mergeRequests, resp, err := client.MergeRequests.ListProjectMergeRequests(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, mr := range mergeRequests {
+			if mr.UpdatedAt != nil && mr.UpdatedAt.Before(since) {
+				return 0, nil
+			}
+			if mr.MergedAt == nil || mr.MergedAt.Before(since) {
+				continue
+			}
+			fmt.Fprintf(&notes, "- %s (!%d) @%s\n", mr.Title, mr.IID, mr.Author.Username)
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return notes.String(), nil
+}
+
+// createReleaseOptions adds the milestones to the create options
+type createReleaseOptions struct {
+	*gitlab.CreateReleaseOptions
+	Milestones []string `url:"milestones,omitempty" json:"milestones,omitempty"`
+}
+
+// CreateRelease creates a release associated with the given milestones. The
+// request is built by hand since the go-gitlab create options lack the milestones.
+func (client *Client) CreateRelease(pid string, opt *gitlab.CreateReleaseOptions, milestones []string) (*gitlab.Release, error) {
+
+	u := fmt.Sprintf("projects/%s/releases", pathEscape(pid))
+	req, err := client.NewRequest("POST", u, &createReleaseOptions{opt, milestones}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	release := new(gitlab.Release)
+	if _, err := client.Do(req, release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// BlameRange rapresents consecutive lines of a file last modified by the same commit
+type BlameRange struct {
+	Commit *gitlab.Commit `json:"commit"`
+	Lines  []string       `json:"lines"`
+}
+
+// GetFileBlame returns the blame of a repository file at the given ref. The request
+// is built by hand since the go-gitlab version in use lacks the blame endpoint.
+func (client *Client) GetFileBlame(pid, file, ref string) ([]*BlameRange, error) {
+
+	u := fmt.Sprintf("projects/%s/repository/files/%s/blame", pathEscape(pid), pathEscape(file))
+	opt := &struct {
+		Ref string `url:"ref" json:"ref"`
+	}{ref}
+
+	req, err := client.NewRequest("GET", u, opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges []*BlameRange
+	if _, err := client.Do(req, &ranges); err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+)
+
+// pageSize the number of items requested for every result page
+const pageSize = 100
+
+// WalkPages calls fetch for every result page, starting from the first one.
+// fetch returns the number of the next page, 0 to stop the walk.
+func WalkPages(fetch func(page int) (int, error)) error {
+	for page := 1; page != 0; {
+		next, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+	return nil
+}
+
+// pathEscape escapes a project or group path for the raw requests URL.
+// The dots are escaped too, as go-gitlab does, so that paths ending with
+// something like ".json" are not taken as a response format.
+func pathEscape(s string) string {
+	return strings.Replace(url.PathEscape(s), ".", "%2E", -1)
+}
@@ -0,0 +1,337 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/xanzy/go-gitlab"
+)
+
+// runnerWorkers the number of concurrent requests used to fetch the runner details
+const runnerWorkers = 8
+
+// UntaggedJobs the tag used to summarize the jobs without tags
+const UntaggedJobs = "(untagged)"
+
+// ListRunners returns the runners of a project or a group. If the owner is nil,
+// it returns all the runners of the instance when all is set, otherwise the
+// runners available to the user.
+func (client *Client) ListRunners(owner *Owner, all bool) ([]*gitlab.Runner, error) {
+
+	var runners []*gitlab.Runner
+	listOptions := gitlab.ListOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		listOptions.Page = page
+
+		var p []*gitlab.Runner
+		var resp *gitlab.Response
+		var err error
+		switch {
+		case owner != nil && owner.Group != "":
+			p, resp, err = client.listGroupRunners(owner.Group, &listOptions)
+		case owner != nil:
+			p, resp, err = client.Runners.ListProjectRunners(owner.Project, &gitlab.ListProjectRunnersOptions{ListOptions: listOptions})
+		case all:
+			p, resp, err = client.Runners.ListAllRunners(&gitlab.ListRunnersOptions{ListOptions: listOptions})
+		default:
+			p, resp, err = client.Runners.ListRunners(&gitlab.ListRunnersOptions{ListOptions: listOptions})
+		}
+		if err != nil {
+			return 0, err
+		}
+		runners = append(runners, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return runners, nil
+}
+
+// listGroupRunners returns a page of the runners of a group. The request is built
+// by hand since the go-gitlab version in use lacks the group runners endpoint.
+func (client *Client) listGroupRunners(gid string, opt *gitlab.ListOptions) ([]*gitlab.Runner, *gitlab.Response, error) {
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("groups/%s/runners", pathEscape(gid)), opt, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var runners []*gitlab.Runner
+	resp, err := client.Do(req, &runners)
+	if err != nil {
+		return nil, resp, err
+	}
+	return runners, resp, nil
+}
+
+// RunnerDetails rapresents the details of a runner. RunUntagged is read from the
+// raw response since the go-gitlab RunnerDetails lacks it.
+type RunnerDetails struct {
+	gitlab.RunnerDetails
+	RunUntagged bool `json:"run_untagged"`
+}
+
+// GetRunnerDetails returns the details of a runner
+func (client *Client) GetRunnerDetails(runnerID int) (*RunnerDetails, error) {
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("runners/%d", runnerID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := new(RunnerDetails)
+	if _, err := client.Do(req, runner); err != nil {
+		return nil, err
+	}
+	return runner, nil
+}
+
+// ListRunnerDetails returns the details, such as the tags, of the given runners.
+// The runners whose details cannot be read are skipped and reported in the errors.
+func (client *Client) ListRunnerDetails(runners []*gitlab.Runner) ([]*RunnerDetails, []error) {
+
+	details := make([]*RunnerDetails, len(runners))
+	errs := make([]error, len(runners))
+	util.ForEach(len(runners), runnerWorkers, func(i int) {
+		details[i], errs[i] = client.GetRunnerDetails(runners[i].ID)
+	})
+
+	var readable []*RunnerDetails
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("runner %d: %v", runners[i].ID, err))
+			continue
+		}
+		readable = append(readable, details[i])
+	}
+
+	return readable, failed
+}
+
+// ListRunnerJobs returns the most recent jobs with the given status processed by a runner.
+// The pages are walked until the limit is reached, or until the last page if the limit is not positive.
+func (client *Client) ListRunnerJobs(runnerID int, status string, limit int) ([]*Job, error) {
+
+	var jobs []*Job
+	opt := &gitlab.ListRunnerJobsOptions{
+		OrderBy:     gitlab.String("id"),
+		Sort:        gitlab.String("desc"),
+		ListOptions: gitlab.ListOptions{PerPage: pageSize},
+	}
+	if status != "" {
+		opt.Status = gitlab.String(status)
+	}
+	if limit > 0 && limit < opt.PerPage {
+		opt.PerPage = limit
+	}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		req, err := client.NewRequest("GET", fmt.Sprintf("runners/%d/jobs", runnerID), opt, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var p []*Job
+		resp, err := client.Do(req, &p)
+		if err != nil {
+			return 0, err
+		}
+		jobs = append(jobs, p...)
+		if limit > 0 && len(jobs) >= limit {
+			return 0, nil
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 && len(jobs) > limit {
+		return jobs[:limit], nil
+	}
+	return jobs, nil
+}
+
+// PendingJob rapresents a job waiting for a runner
+type PendingJob struct {
+	Job
+	// QueuedDuration the seconds the job has been pending, as reported by the API.
+	// It is read from the raw response since the go-gitlab Job lacks it.
+	QueuedDuration float64 `json:"queued_duration"`
+	// ProjectID the ID of the project of the job
+	ProjectID int `json:"project_id"`
+	// ProtectedRef whether the branch or the tag of the job is protected
+	ProtectedRef bool `json:"protected_ref"`
+}
+
+// ListPendingJobs returns the pending jobs of a project or, for a group,
+// of all the projects of the group and its subgroups
+func (client *Client) ListPendingJobs(owner *Owner) ([]*PendingJob, error) {
+
+	var projects []*gitlab.Project
+	if owner.Group != "" {
+		groupProjects, err := client.ListAllGroupProjects(owner.Group, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, project := range groupProjects {
+			if project.JobsEnabled {
+				projects = append(projects, project)
+			}
+		}
+	} else {
+		project, _, err := client.Projects.GetProject(owner.Project, nil)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	var jobs []*PendingJob
+	for _, project := range projects {
+		opt := &gitlab.ListJobsOptions{
+			Scope:       []gitlab.BuildStateValue{gitlab.Pending},
+			ListOptions: gitlab.ListOptions{PerPage: pageSize},
+		}
+
+		var projectJobs []*PendingJob
+		err := WalkPages(func(page int) (int, error) {
+			opt.Page = page
+			req, err := client.NewRequest("GET", fmt.Sprintf("projects/%d/jobs", project.ID), opt, nil)
+			if err != nil {
+				return 0, err
+			}
+
+			var p []*PendingJob
+			resp, err := client.Do(req, &p)
+			if err != nil {
+				return 0, err
+			}
+			projectJobs = append(projectJobs, p...)
+			return resp.NextPage, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// the protection is read once for every ref of the project
+		protected := make(map[string]bool)
+		for _, job := range projectJobs {
+			key := "heads/" + job.Ref
+			if job.Tag {
+				key = "tags/" + job.Ref
+			}
+			if _, ok := protected[key]; !ok {
+				protected[key], err = client.isProtectedRef(project.ID, job.Ref, job.Tag)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			job.ProjectID = project.ID
+			job.ProtectedRef = protected[key]
+		}
+		jobs = append(jobs, projectJobs...)
+	}
+
+	return jobs, nil
+}
+
+// isProtectedRef returns true if the given branch or tag of a project is protected.
+// The refs not found, such as the merge request refs, are not protected.
+func (client *Client) isProtectedRef(pid int, ref string, tag bool) (bool, error) {
+
+	if !tag {
+		branch, resp, err := client.Branches.GetBranch(pid, ref)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return false, nil
+			}
+			return false, err
+		}
+		return branch.Protected, nil
+	}
+
+	protected := false
+	opt := &gitlab.ListProtectedTagsOptions{PerPage: pageSize}
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		tags, resp, err := client.ProtectedTags.ListProtectedTags(pid, opt)
+		if err != nil {
+			return 0, err
+		}
+		for _, t := range tags {
+			// the protected tag names can use * as a wildcard
+			pattern, err := util.CompileGlob(t.Name)
+			if err != nil {
+				protected = protected || t.Name == ref
+				continue
+			}
+			protected = protected || pattern.MatchString(ref)
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return protected, nil
+}
+
+// RunnerCanPickJob returns true if the runner is online, active and allowed to pick
+// the given job by its tags, by its access level and, for the project runners, by
+// the projects it is assigned to
+func RunnerCanPickJob(runner *RunnerDetails, job *PendingJob) bool {
+
+	if !runner.Online || !runner.Active {
+		return false
+	}
+
+	if runner.AccessLevel == "ref_protected" && !job.ProtectedRef {
+		return false
+	}
+
+	// the project runners, locked or not, pick only the jobs of their projects
+	if !runner.IsShared && len(runner.Projects) > 0 {
+		assigned := false
+		for _, project := range runner.Projects {
+			if project.ID == job.ProjectID {
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			return false
+		}
+	}
+
+	if len(job.TagList) == 0 {
+		return runner.RunUntagged
+	}
+
+	tags := make(map[string]bool)
+	for _, tag := range runner.TagList {
+		tags[tag] = true
+	}
+	for _, tag := range job.TagList {
+		if !tags[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// JobTags returns the tags of a job, or UntaggedJobs if the job has no tags
+func JobTags(job *Job) []string {
+	if len(job.TagList) == 0 {
+		return []string{UntaggedJobs}
+	}
+	return job.TagList
+}
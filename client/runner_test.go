@@ -0,0 +1,61 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestRunnerCanPickJob(t *testing.T) {
+	runner := func(online, active, runUntagged bool, tags ...string) *RunnerDetails {
+		return &RunnerDetails{
+			RunnerDetails: gitlab.RunnerDetails{Online: online, Active: active, IsShared: true, TagList: tags},
+			RunUntagged:   runUntagged,
+		}
+	}
+	protectedRunner := runner(true, true, true)
+	protectedRunner.AccessLevel = "ref_protected"
+	projectRunner := runner(true, true, true)
+	projectRunner.IsShared = false
+	projectRunner.Locked = true
+	projectRunner.Projects = append(projectRunner.Projects, struct {
+		ID                int    `json:"id"`
+		Name              string `json:"name"`
+		NameWithNamespace string `json:"name_with_namespace"`
+		Path              string `json:"path"`
+		PathWithNamespace string `json:"path_with_namespace"`
+	}{ID: 1})
+
+	job := func(tags ...string) *PendingJob {
+		return &PendingJob{Job: Job{TagList: tags}, ProjectID: 1}
+	}
+	protectedJob := job()
+	protectedJob.ProtectedRef = true
+	otherProjectJob := job()
+	otherProjectJob.ProjectID = 2
+
+	tests := []struct {
+		name   string
+		runner *RunnerDetails
+		job    *PendingJob
+		want   bool
+	}{
+		{"untagged job, runner running untagged", runner(true, true, true), job(), true},
+		{"untagged job, runner not running untagged", runner(true, true, false, "docker"), job(), false},
+		{"all the job tags", runner(true, true, false, "docker", "linux"), job("docker"), true},
+		{"same tags", runner(true, true, false, "docker", "linux"), job("linux", "docker"), true},
+		{"missing a job tag", runner(true, true, false, "docker"), job("docker", "gpu"), false},
+		{"offline runner", runner(false, true, true, "docker"), job("docker"), false},
+		{"paused runner", runner(true, false, true, "docker"), job("docker"), false},
+		{"protected runner, unprotected ref", protectedRunner, job(), false},
+		{"protected runner, protected ref", protectedRunner, protectedJob, true},
+		{"project runner, job of its project", projectRunner, job(), true},
+		{"project runner, job of another project", projectRunner, otherProjectJob, false},
+	}
+
+	for _, tt := range tests {
+		if got := RunnerCanPickJob(tt.runner, tt.job); got != tt.want {
+			t.Errorf("%s: RunnerCanPickJob() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Snippet rapresents a personal or project snippet. The visibility and the files are
+// read from the raw response since the go-gitlab Snippet lacks them.
+type Snippet struct {
+	gitlab.Snippet
+	Visibility string `json:"visibility"`
+	Files      []*struct {
+		Path   string `json:"path"`
+		RawURL string `json:"raw_url"`
+	} `json:"files"`
+}
+
+// SnippetFile rapresents a file of a snippet
+type SnippetFile struct {
+	Path    string `json:"file_path"`
+	Content string `json:"content"`
+}
+
+// SnippetOptions rapresents the attributes of a snippet to create or update.
+// Empty attributes are left unchanged on update, while the given files are
+// always written, even if empty.
+type SnippetOptions struct {
+	Title       string
+	Description string
+	Visibility  string
+	Files       []*SnippetFile
+}
+
+// snippetFileAction rapresents a change of a snippet file
+type snippetFileAction struct {
+	Action   string `json:"action"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content"`
+}
+
+// ListSnippets returns the snippets of a project or, if the project is empty, of the user
+// walking every result page
+func (client *Client) ListSnippets(pid string) ([]*Snippet, error) {
+
+	var snippets []*Snippet
+	opt := &gitlab.ListOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		req, err := client.NewRequest("GET", snippetsURL(pid), opt, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var p []*Snippet
+		resp, err := client.Do(req, &p)
+		if err != nil {
+			return 0, err
+		}
+		snippets = append(snippets, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snippets, nil
+}
+
+// GetSnippet returns a project snippet or, if the project is empty, a personal snippet
+func (client *Client) GetSnippet(pid string, snippetID int) (*Snippet, error) {
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("%s/%d", snippetsURL(pid), snippetID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snippet := new(Snippet)
+	if _, err := client.Do(req, snippet); err != nil {
+		return nil, err
+	}
+	return snippet, nil
+}
+
+// GetSnippetContent returns the raw content of a project snippet or, if the project is empty, of a personal snippet
+func (client *Client) GetSnippetContent(pid string, snippetID int) ([]byte, error) {
+
+	if pid != "" {
+		content, _, err := client.ProjectSnippets.SnippetContent(pid, snippetID)
+		return content, err
+	}
+
+	content, _, err := client.Snippets.SnippetContent(snippetID)
+	return content, err
+}
+
+// CreateSnippet creates a project snippet or, if the project is empty, a personal snippet
+func (client *Client) CreateSnippet(pid string, opt *SnippetOptions) (*Snippet, error) {
+
+	body := &struct {
+		Title       string         `json:"title"`
+		Description string         `json:"description,omitempty"`
+		Visibility  string         `json:"visibility,omitempty"`
+		Files       []*SnippetFile `json:"files"`
+	}{opt.Title, opt.Description, opt.Visibility, opt.Files}
+
+	req, err := client.NewRequest("POST", snippetsURL(pid), body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snippet := new(Snippet)
+	if _, err := client.Do(req, snippet); err != nil {
+		return nil, err
+	}
+	return snippet, nil
+}
+
+// UpdateSnippet updates a project snippet or, if the project is empty, a personal snippet.
+// The given files are updated if they already exist in the snippet, created otherwise.
+func (client *Client) UpdateSnippet(pid string, snippetID int, opt *SnippetOptions) (*Snippet, error) {
+
+	snippet, err := client.GetSnippet(pid, snippetID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, file := range snippet.Files {
+		existing[file.Path] = true
+	}
+
+	var files []*snippetFileAction
+	for _, file := range opt.Files {
+		action := "create"
+		if existing[file.Path] {
+			action = "update"
+		}
+		files = append(files, &snippetFileAction{action, file.Path, file.Content})
+	}
+
+	body := &struct {
+		Title       string               `json:"title,omitempty"`
+		Description string               `json:"description,omitempty"`
+		Visibility  string               `json:"visibility,omitempty"`
+		Files       []*snippetFileAction `json:"files,omitempty"`
+	}{opt.Title, opt.Description, opt.Visibility, files}
+
+	req, err := client.NewRequest("PUT", fmt.Sprintf("%s/%d", snippetsURL(pid), snippetID), body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snippet = new(Snippet)
+	if _, err := client.Do(req, snippet); err != nil {
+		return nil, err
+	}
+	return snippet, nil
+}
+
+// DeleteSnippet deletes a project snippet or, if the project is empty, a personal snippet
+func (client *Client) DeleteSnippet(pid string, snippetID int) error {
+
+	if pid != "" {
+		_, err := client.ProjectSnippets.DeleteSnippet(pid, snippetID)
+		return err
+	}
+
+	_, err := client.Snippets.DeleteSnippet(snippetID)
+	return err
+}
+
+// snippetsURL returns the URL of the project snippets or, if the project is empty, of the personal snippets
+func snippetsURL(pid string) string {
+	if pid != "" {
+		return fmt.Sprintf("projects/%s/snippets", pathEscape(pid))
+	}
+	return "snippets"
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// listTagsOptions adds the search filter to the list options
+type listTagsOptions struct {
+	gitlab.ListTagsOptions
+	Search *string `url:"search,omitempty" json:"search,omitempty"`
+}
+
+// ListTags returns the tags of a project, optionally filtered by a search term
+func (client *Client) ListTags(pid string, opt *gitlab.ListTagsOptions, search string) ([]*gitlab.Tag, error) {
+
+	if search == "" {
+		tags, _, err := client.Tags.ListTags(pid, opt)
+		return tags, err
+	}
+
+	u := fmt.Sprintf("projects/%s/repository/tags", pathEscape(pid))
+	req, err := client.NewRequest("GET", u, &listTagsOptions{*opt, gitlab.String(search)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*gitlab.Tag
+	if _, err := client.Do(req, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// GetUserID returns the id of the user with the given username
+func (client *Client) GetUserID(username string) (int, error) {
+
+	opt := &gitlab.ListUsersOptions{Username: gitlab.String(username)}
+	users, _, err := client.Users.ListUsers(opt)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(users) == 0 {
+		return 0, fmt.Errorf("user %q not found", username)
+	}
+
+	return users[0].ID, nil
+}
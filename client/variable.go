@@ -0,0 +1,213 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Variable rapresents a project or group CI/CD variable
+type Variable struct {
+	Key              string `json:"key"`
+	Value            string `json:"value"`
+	VariableType     string `json:"variable_type"`
+	Protected        bool   `json:"protected"`
+	Masked           bool   `json:"masked"`
+	EnvironmentScope string `json:"environment_scope,omitempty"`
+}
+
+// ListCIVariables returns the CI/CD variables of a project or a group walking every result page.
+// The pages are requested by hand and decoded straight into Variable for both owners.
+func (client *Client) ListCIVariables(owner *Owner) ([]*Variable, error) {
+
+	u := fmt.Sprintf("projects/%s/variables", pathEscape(owner.Project))
+	if owner.Group != "" {
+		u = fmt.Sprintf("groups/%s/variables", pathEscape(owner.Group))
+	}
+
+	var variables []*Variable
+	opt := &gitlab.ListOptions{PerPage: pageSize}
+
+	err := WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		req, err := client.NewRequest("GET", u, opt, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var p []*Variable
+		resp, err := client.Do(req, &p)
+		if err != nil {
+			return 0, err
+		}
+		variables = append(variables, p...)
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+// projectVariableFilter selects a project variable by environment scope,
+// since variables with the same key can exist in different scopes
+type projectVariableFilter struct {
+	EnvironmentScope string `url:"filter[environment_scope],omitempty" json:"environment_scope,omitempty"`
+}
+
+// updateProjectVariableOptions adds the environment scope filter to the update options
+type updateProjectVariableOptions struct {
+	*gitlab.UpdateProjectVariableOptions
+	Filter *projectVariableFilter `url:"-" json:"filter,omitempty"`
+}
+
+// GetCIVariable returns a CI/CD variable of a project or a group.
+// The environment scope is supported only by project variables.
+func (client *Client) GetCIVariable(owner *Owner, key, scope string) (*Variable, error) {
+
+	if owner.Group != "" {
+		v, _, err := client.GroupVariables.GetVariable(owner.Group, key)
+		if err != nil {
+			return nil, err
+		}
+		return fromGroupVariable(v), nil
+	}
+
+	v, _, err := client.getProjectVariable(owner.Project, key, scope)
+	if err != nil {
+		return nil, err
+	}
+	return fromProjectVariable(v), nil
+}
+
+// SetCIVariable creates or updates a CI/CD variable of a project or a group.
+// A project variable is updated only if it exists in the same environment scope.
+func (client *Client) SetCIVariable(owner *Owner, variable *Variable) error {
+
+	exists := true
+	var resp *gitlab.Response
+	var err error
+	if owner.Group != "" {
+		_, resp, err = client.GroupVariables.GetVariable(owner.Group, variable.Key)
+	} else {
+		_, resp, err = client.getProjectVariable(owner.Project, variable.Key, variable.EnvironmentScope)
+	}
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return err
+		}
+		exists = false
+	}
+
+	variableType := gitlab.VariableTypeValue(variable.VariableType)
+
+	if owner.Group != "" {
+		if exists {
+			_, _, err = client.GroupVariables.UpdateVariable(owner.Group, variable.Key, &gitlab.UpdateGroupVariableOptions{
+				Value:        gitlab.String(variable.Value),
+				VariableType: &variableType,
+				Protected:    gitlab.Bool(variable.Protected),
+				Masked:       gitlab.Bool(variable.Masked),
+			})
+		} else {
+			_, _, err = client.GroupVariables.CreateVariable(owner.Group, &gitlab.CreateGroupVariableOptions{
+				Key:          gitlab.String(variable.Key),
+				Value:        gitlab.String(variable.Value),
+				VariableType: &variableType,
+				Protected:    gitlab.Bool(variable.Protected),
+				Masked:       gitlab.Bool(variable.Masked),
+			})
+		}
+		return err
+	}
+
+	if exists {
+		opt := &updateProjectVariableOptions{
+			UpdateProjectVariableOptions: &gitlab.UpdateProjectVariableOptions{
+				Value:            gitlab.String(variable.Value),
+				VariableType:     &variableType,
+				Protected:        gitlab.Bool(variable.Protected),
+				Masked:           gitlab.Bool(variable.Masked),
+				EnvironmentScope: gitlab.String(variable.EnvironmentScope),
+			},
+			Filter: &projectVariableFilter{EnvironmentScope: variable.EnvironmentScope},
+		}
+
+		req, err := client.NewRequest("PUT", projectVariableURL(owner.Project, variable.Key), opt, nil)
+		if err != nil {
+			return err
+		}
+		_, err = client.Do(req, nil)
+		return err
+	}
+
+	_, _, err = client.ProjectVariables.CreateVariable(owner.Project, &gitlab.CreateProjectVariableOptions{
+		Key:              gitlab.String(variable.Key),
+		Value:            gitlab.String(variable.Value),
+		VariableType:     &variableType,
+		Protected:        gitlab.Bool(variable.Protected),
+		Masked:           gitlab.Bool(variable.Masked),
+		EnvironmentScope: gitlab.String(variable.EnvironmentScope),
+	})
+	return err
+}
+
+// DeleteCIVariable deletes a CI/CD variable of a project or a group.
+// The environment scope is supported only by project variables.
+func (client *Client) DeleteCIVariable(owner *Owner, key, scope string) error {
+
+	if owner.Group != "" {
+		_, err := client.GroupVariables.RemoveVariable(owner.Group, key)
+		return err
+	}
+
+	req, err := client.NewRequest("DELETE", projectVariableURL(owner.Project, key), &projectVariableFilter{EnvironmentScope: scope}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(req, nil)
+	return err
+}
+
+// getProjectVariable returns the project variable with the given key in the given environment scope
+func (client *Client) getProjectVariable(pid, key, scope string) (*gitlab.ProjectVariable, *gitlab.Response, error) {
+
+	req, err := client.NewRequest("GET", projectVariableURL(pid, key), &projectVariableFilter{EnvironmentScope: scope}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := new(gitlab.ProjectVariable)
+	resp, err := client.Do(req, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+func projectVariableURL(pid, key string) string {
+	return fmt.Sprintf("projects/%s/variables/%s", pathEscape(pid), pathEscape(key))
+}
+
+func fromProjectVariable(v *gitlab.ProjectVariable) *Variable {
+	return &Variable{
+		Key:              v.Key,
+		Value:            v.Value,
+		VariableType:     string(v.VariableType),
+		Protected:        v.Protected,
+		Masked:           v.Masked,
+		EnvironmentScope: v.EnvironmentScope,
+	}
+}
+
+func fromGroupVariable(v *gitlab.GroupVariable) *Variable {
+	return &Variable{
+		Key:          v.Key,
+		Value:        v.Value,
+		VariableType: string(v.VariableType),
+		Protected:    v.Protected,
+		Masked:       v.Masked,
+	}
+}
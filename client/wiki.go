@@ -0,0 +1,16 @@
+package client
+
+import (
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListWikiPages returns the wiki pages of a project, optionally with their content
+func (client *Client) ListWikiPages(pid string, withContent bool) ([]*gitlab.Wiki, error) {
+
+	pages, _, err := client.Wikis.ListWikis(pid, &gitlab.ListWikisOptions{WithContent: gitlab.Bool(withContent)})
+	if err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// branchCmd represents the branches command
+var branchCmd = &cobra.Command{
+	Use:   "branch",
+	Short: "Manage branches",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listBranchesCmd represents the list branches command
+var listBranchesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the branches of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		search, _ := cmd.Flags().GetString("search")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: limit}}
+		if search != "" {
+			opt.Search = gitlab.String(search)
+		}
+
+		branches, _, err := gitlabClient.Branches.ListBranches(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(branches)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "DEFAULT", "PROTECTED", "MERGED", "COMMIT", "COMMITTED AT"})
+		for _, branch := range branches {
+			tw.AppendRow(table.Row{branch.Name, branch.Default, branch.Protected, branch.Merged, branch.Commit.ShortID, branch.Commit.CommittedDate})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// createBranchCmd represents the create branch command
+var createBranchCmd = &cobra.Command{
+	Use:   "create BRANCH",
+	Short: "Create a branch",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+
+		opt := &gitlab.CreateBranchOptions{Branch: gitlab.String(args[0]), Ref: gitlab.String(ref)}
+		branch, _, err := gitlabClient.Branches.CreateBranch(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Branch %s created at %s\n", branch.Name, branch.Commit.ShortID)
+	},
+}
+
+// deleteBranchCmd represents the delete branch command
+var deleteBranchCmd = &cobra.Command{
+	Use:   "delete BRANCH",
+	Short: "Delete a branch",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		if _, err := gitlabClient.Branches.DeleteBranch(project, args[0]); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Branch %s deleted\n", args[0])
+	},
+}
+
+// protectBranchCmd represents the protect branch command
+var protectBranchCmd = &cobra.Command{
+	Use:   "protect BRANCH",
+	Short: "Protect a branch or a wildcard, e.g. release/*",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		push, _ := cmd.Flags().GetString("push")
+		merge, _ := cmd.Flags().GetString("merge")
+
+		pushAccessLevel, err := client.ParseAccessLevel(push)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mergeAccessLevel, err := client.ParseAccessLevel(merge)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opt := &gitlab.ProtectRepositoryBranchesOptions{
+			Name:             gitlab.String(args[0]),
+			PushAccessLevel:  gitlab.AccessLevel(pushAccessLevel),
+			MergeAccessLevel: gitlab.AccessLevel(mergeAccessLevel),
+		}
+		if _, _, err := gitlabClient.ProtectedBranches.ProtectRepositoryBranches(project, opt); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Branch %s protected: push %s, merge %s\n", args[0], push, merge)
+	},
+}
+
+// unprotectBranchCmd represents the unprotect branch command
+var unprotectBranchCmd = &cobra.Command{
+	Use:   "unprotect BRANCH",
+	Short: "Unprotect a branch",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		if _, err := gitlabClient.ProtectedBranches.UnprotectRepositoryBranches(project, args[0]); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Branch %s unprotected\n", args[0])
+	},
+}
+
+// pruneBranchesCmd represents the prune branches command
+var pruneBranchesCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete the stale branches",
+	Long: `Delete the stale branches
+
+Only the branches merged into the default branch are deleted, unless --merged=false is set.
+The default and the protected branches are never deleted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		merged, _ := cmd.Flags().GetBool("merged")
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		age, err := util.ParseDuration(olderThan)
+		if err != nil {
+			log.Fatal(err)
+		}
+		limit := time.Now().Add(-age)
+
+		branches, err := gitlabClient.ListAllBranches(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var selected []*gitlab.Branch
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "MERGED", "COMMITTED AT"})
+		for _, branch := range branches {
+			if branch.Default || branch.Protected || (merged && !branch.Merged) {
+				continue
+			}
+			if branch.Commit == nil || branch.Commit.CommittedDate == nil || branch.Commit.CommittedDate.After(limit) {
+				continue
+			}
+
+			selected = append(selected, branch)
+			tw.AppendRow(table.Row{branch.Name, branch.Merged, branch.Commit.CommittedDate})
+		}
+
+		if len(selected) == 0 {
+			fmt.Println("No branches to delete")
+			return
+		}
+		fmt.Println(tw.Render())
+
+		if dryRun {
+			fmt.Printf("\n%d branches would be deleted\n", len(selected))
+			return
+		}
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete %d branches?", len(selected))) {
+			log.Fatal("aborted")
+		}
+
+		for _, branch := range selected {
+			if _, err := gitlabClient.Branches.DeleteBranch(project, branch.Name); err != nil {
+				log.Fatalf("%s: %v", branch.Name, err)
+			}
+		}
+
+		fmt.Printf("\n%d branches deleted\n", len(selected))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(branchCmd)
+	branchCmd.AddCommand(listBranchesCmd)
+	branchCmd.AddCommand(createBranchCmd)
+	branchCmd.AddCommand(deleteBranchCmd)
+	branchCmd.AddCommand(protectBranchCmd)
+	branchCmd.AddCommand(unprotectBranchCmd)
+	branchCmd.AddCommand(pruneBranchesCmd)
+
+	branchCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(branchCmd.PersistentFlags(), "project")
+
+	listBranchesCmd.Flags().String("search", "", "Search a branch")
+	listBranchesCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	createBranchCmd.Flags().StringP("ref", "r", "", "Set the ref to create the branch from")
+	cobra.MarkFlagRequired(createBranchCmd.Flags(), "ref")
+
+	protectBranchCmd.Flags().String("push", "maintainer", "Set the access level allowed to push: none, developer or maintainer")
+	protectBranchCmd.Flags().String("merge", "maintainer", "Set the access level allowed to merge: none, developer or maintainer")
+
+	pruneBranchesCmd.Flags().Bool("merged", true, "Delete only the branches merged into the default branch")
+	pruneBranchesCmd.Flags().String("older-than", "60d", "Delete only the branches whose last commit is older than the given age, e.g. 60d")
+	pruneBranchesCmd.Flags().Bool("dry-run", false, "Only report the branches that would be deleted")
+	pruneBranchesCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
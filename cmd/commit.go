@@ -0,0 +1,264 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// commitCmd represents the commits command
+var commitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Inspect commits",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listCommitsCmd represents the list commits command
+var listCommitsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the commits of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+		since, _ := cmd.Flags().GetString("since")
+		author, _ := cmd.Flags().GetString("author")
+		path, _ := cmd.Flags().GetString("path")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListCommitsOptions{ListOptions: gitlab.ListOptions{Page: 1}}
+		if author == "" {
+			// the author filter is applied locally and uses its own page size
+			opt.PerPage = limit
+		}
+		if ref != "" {
+			opt.RefName = gitlab.String(ref)
+		}
+		if since != "" {
+			sinceTime, err := parseTimeOrAge(since)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.Since = gitlab.Time(sinceTime)
+		}
+		if path != "" {
+			opt.Path = gitlab.String(path)
+		}
+
+		var commits []*gitlab.Commit
+		var err error
+		if author != "" {
+			commits, err = gitlabClient.ListCommitsByAuthor(project, opt, author, limit)
+		} else {
+			commits, _, err = gitlabClient.Commits.ListCommits(project, opt)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(commits)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"SHA", "AUTHOR", "DATE", "TITLE"})
+		for _, commit := range commits {
+			tw.AppendRow(table.Row{commit.ShortID, commit.AuthorName, commit.AuthoredDate, commit.Title})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showCommitCmd represents the show commit command
+var showCommitCmd = &cobra.Command{
+	Use:   "show SHA",
+	Short: "Show a commit with its diff and pipelines",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		noDiff, _ := cmd.Flags().GetBool("no-diff")
+
+		commit, _, err := gitlabClient.Commits.GetCommit(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opt := &gitlab.ListProjectPipelinesOptions{SHA: gitlab.String(commit.ID)}
+		pipelines, _, err := gitlabClient.Pipelines.ListProjectPipelines(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(struct {
+				*gitlab.Commit
+				Pipelines interface{} `json:"pipelines"`
+			}{commit, pipelines})
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"SHA:", commit.ID})
+		tw.AppendRow(table.Row{"Author:", fmt.Sprintf("%s <%s>", commit.AuthorName, commit.AuthorEmail)})
+		tw.AppendRow(table.Row{"Date:", commit.AuthoredDate})
+		tw.AppendRow(table.Row{"Parents:", strings.Join(commit.ParentIDs, ", ")})
+		if commit.Stats != nil {
+			tw.AppendRow(table.Row{"Stats:", fmt.Sprintf("%d insertions(+), %d deletions(-)", commit.Stats.Additions, commit.Stats.Deletions)})
+		}
+		fmt.Println(tw.Render())
+
+		fmt.Printf("\n%s\n", commit.Message)
+
+		sw := util.NewStatusWriter()
+		fmt.Print("\nPipelines:\n")
+		twPipelines := util.NewTableWriter()
+		twPipelines.AppendHeader(table.Row{"ID", "REF", "STATUS", "URL"})
+		for _, pipeline := range pipelines {
+			twPipelines.AppendRow(table.Row{pipeline.ID, pipeline.Ref, sw.Sprintf(pipeline.Status), pipeline.WebURL})
+		}
+		fmt.Println(twPipelines.Render())
+
+		if !noDiff {
+			diffs, err := gitlabClient.ListAllCommitDiffs(project, commit.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Println()
+			for _, diff := range diffs {
+				printDiff(diff.OldPath, diff.NewPath, diff.Diff)
+			}
+		}
+	},
+}
+
+// commitStatusCmd represents the commit status command
+var commitStatusCmd = &cobra.Command{
+	Use:   "status SHA",
+	Short: "List the statuses of a commit",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+
+		opt := &gitlab.GetCommitStatusesOptions{All: gitlab.Bool(true)}
+		if ref != "" {
+			opt.Ref = gitlab.String(ref)
+		}
+
+		statuses, err := gitlabClient.ListAllCommitStatuses(project, args[0], opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(statuses)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "STATUS", "REF", "DESCRIPTION", "URL", "CREATED AT"})
+		for _, status := range statuses {
+			tw.AppendRow(table.Row{status.Name, sw.Sprintf(status.Status), status.Ref, status.Description, status.TargetURL, status.CreatedAt})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// setCommitStatusCmd represents the set commit status command
+var setCommitStatusCmd = &cobra.Command{
+	Use:   "set SHA STATE",
+	Short: "Set the status of a commit from an external system",
+	Long: `Set the status of a commit from an external system
+
+STATE is one of pending, running, success, failed or canceled.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+		name, _ := cmd.Flags().GetString("name")
+		targetURL, _ := cmd.Flags().GetString("target-url")
+		description, _ := cmd.Flags().GetString("description")
+
+		opt := &gitlab.SetCommitStatusOptions{
+			State: gitlab.BuildStateValue(args[1]),
+			Name:  gitlab.String(name),
+		}
+		if ref != "" {
+			opt.Ref = gitlab.String(ref)
+		}
+		if targetURL != "" {
+			opt.TargetURL = gitlab.String(targetURL)
+		}
+		if description != "" {
+			opt.Description = gitlab.String(description)
+		}
+
+		status, _, err := gitlabClient.Commits.SetCommitStatus(project, args[0], opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		sw := util.NewStatusWriter()
+		fmt.Printf("Status %s of %s set to %s\n", status.Name, args[0], sw.Sprintf(status.Status))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(commitCmd)
+	commitCmd.AddCommand(listCommitsCmd)
+	commitCmd.AddCommand(showCommitCmd)
+	commitCmd.AddCommand(commitStatusCmd)
+	commitStatusCmd.AddCommand(setCommitStatusCmd)
+
+	commitCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(commitCmd.PersistentFlags(), "project")
+
+	listCommitsCmd.Flags().StringP("ref", "r", "", "Set the ref. The default value is the project default branch")
+	listCommitsCmd.Flags().String("since", "", "List only the commits after a date (YYYY-MM-DD) or an age (e.g. 7d)")
+	listCommitsCmd.Flags().String("author", "", fmt.Sprintf("Filter by author name or email. Without --since only the latest %d commits are searched", client.AuthorSearchLimit))
+	listCommitsCmd.Flags().String("path", "", "List only the commits that changed the path")
+	listCommitsCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	showCommitCmd.Flags().Bool("no-diff", false, "Do not show the diff")
+
+	commitStatusCmd.PersistentFlags().StringP("ref", "r", "", "Set the ref of the status")
+
+	setCommitStatusCmd.Flags().StringP("name", "n", "default", "Set the name of the status")
+	setCommitStatusCmd.Flags().String("target-url", "", "Set the URL of the external system")
+	setCommitStatusCmd.Flags().StringP("description", "d", "", "Set the description of the status")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// deploymentCmd represents the deployments command
+var deploymentCmd = &cobra.Command{
+	Use:   "deployment",
+	Short: "Manage deployments",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listDeploymentsCmd represents the list deployments command
+var listDeploymentsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the deployments of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		environment, _ := cmd.Flags().GetString("environment")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListProjectDeploymentsOptions{
+			OrderBy:     gitlab.String("created_at"),
+			Sort:        gitlab.String("desc"),
+			ListOptions: gitlab.ListOptions{Page: 1, PerPage: limit},
+		}
+		if environment != "" {
+			opt.Environment = gitlab.String(environment)
+		}
+
+		deployments, _, err := gitlabClient.Deployments.ListProjectDeployments(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(deployments)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "ENVIRONMENT", "REF", "SHA", "PIPELINE", "STATUS", "USER", "CREATED AT"})
+		for _, deployment := range deployments {
+			environmentName := ""
+			if deployment.Environment != nil {
+				environmentName = deployment.Environment.Name
+			}
+			username := ""
+			if deployment.User != nil {
+				username = deployment.User.Username
+			}
+			tw.AppendRow(table.Row{
+				deployment.ID,
+				environmentName,
+				deployment.Ref,
+				deployment.SHA,
+				deployment.Deployable.Pipeline.ID,
+				sw.Sprintf(deployment.Deployable.Status),
+				username,
+				deployment.CreatedAt,
+			})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// rollbackDeploymentCmd represents the rollback deployment command
+var rollbackDeploymentCmd = &cobra.Command{
+	Use:   "rollback ENVIRONMENT",
+	Short: "Roll back an environment to an earlier deployment",
+	Long: `Roll back an environment to an earlier deployment
+
+The deploy job of the given successful deployment is retried.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		deploymentID, _ := cmd.Flags().GetInt("to")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		deployment, _, err := gitlabClient.Deployments.GetProjectDeployment(project, deploymentID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if deployment.Environment == nil || deployment.Environment.Name != args[0] {
+			log.Fatalf("deployment %d does not belong to the environment %s", deploymentID, args[0])
+		}
+		if deployment.Deployable.Status != "success" {
+			log.Fatalf("deployment %d was not successful", deploymentID)
+		}
+
+		question := fmt.Sprintf("Do you want to roll back %s to %s (%s)?", args[0], deployment.SHA, deployment.Ref)
+		if !yes && !util.Confirm(question) {
+			log.Fatal("aborted")
+		}
+
+		job, _, err := gitlabClient.Jobs.RetryJob(project, deployment.Deployable.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Job %d) %s restarted: %s\n", job.ID, job.Name, job.WebURL)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deploymentCmd)
+	deploymentCmd.AddCommand(listDeploymentsCmd)
+	deploymentCmd.AddCommand(rollbackDeploymentCmd)
+
+	deploymentCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(deploymentCmd.PersistentFlags(), "project")
+
+	listDeploymentsCmd.Flags().StringP("environment", "e", "", "Filter by environment name")
+	listDeploymentsCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	rollbackDeploymentCmd.Flags().Int("to", -1, "Set the id of the deployment to roll back to")
+	cobra.MarkFlagRequired(rollbackDeploymentCmd.Flags(), "to")
+	rollbackDeploymentCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
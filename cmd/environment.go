@@ -0,0 +1,169 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+)
+
+// environmentCmd represents the environments command
+var environmentCmd = &cobra.Command{
+	Use:   "environment",
+	Short: "Manage environments",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listEnvironmentsCmd represents the list environments command
+var listEnvironmentsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the environments of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		environments, err := gitlabClient.ListAllEnvironments(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(environments)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "NAME", "STATE", "URL"})
+		for _, env := range environments {
+			tw.AppendRow(table.Row{env.ID, env.Name, env.State, env.ExternalURL})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showEnvironmentCmd represents the show environment command
+var showEnvironmentCmd = &cobra.Command{
+	Use:   "show ENVIRONMENT",
+	Short: "Show the details of an environment",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		env, err := gitlabClient.ResolveEnvironment(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(env)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"ID:", env.ID})
+		tw.AppendRow(table.Row{"Name:", env.Name})
+		tw.AppendRow(table.Row{"State:", env.State})
+		tw.AppendRow(table.Row{"URL:", env.ExternalURL})
+		if env.LastDeployment != nil {
+			deployment := env.LastDeployment
+			tw.AppendRow(table.Row{"Last deployment:", deployment.ID})
+			tw.AppendRow(table.Row{"Ref:", deployment.Ref})
+			tw.AppendRow(table.Row{"SHA:", deployment.SHA})
+			tw.AppendRow(table.Row{"Deployed at:", deployment.CreatedAt})
+			if deployment.User != nil {
+				tw.AppendRow(table.Row{"Deployed by:", deployment.User.Username})
+			}
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// stopEnvironmentCmd represents the stop environment command
+var stopEnvironmentCmd = &cobra.Command{
+	Use:   "stop ENVIRONMENT",
+	Short: "Stop an environment",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		env, err := gitlabClient.ResolveEnvironment(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if _, err := gitlabClient.Environments.StopEnvironment(project, env.ID); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Environment %s stopped\n", env.Name)
+	},
+}
+
+// deleteEnvironmentCmd represents the delete environment command
+var deleteEnvironmentCmd = &cobra.Command{
+	Use:   "delete ENVIRONMENT",
+	Short: "Delete a stopped environment",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		env, err := gitlabClient.ResolveEnvironment(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete the environment %s?", env.Name)) {
+			log.Fatal("aborted")
+		}
+
+		if _, err := gitlabClient.Environments.DeleteEnvironment(project, env.ID); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Environment %s deleted\n", env.Name)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(environmentCmd)
+	environmentCmd.AddCommand(listEnvironmentsCmd)
+	environmentCmd.AddCommand(showEnvironmentCmd)
+	environmentCmd.AddCommand(stopEnvironmentCmd)
+	environmentCmd.AddCommand(deleteEnvironmentCmd)
+
+	environmentCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(environmentCmd.PersistentFlags(), "project")
+
+	deleteEnvironmentCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
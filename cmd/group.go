@@ -0,0 +1,348 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// groupCmd represents the groups command
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage groups",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listGroupsCmd represents the list groups command
+var listGroupsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Display the list of groups",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		optSearchString, _ := cmd.Flags().GetString("search")
+		optOwned, _ := cmd.Flags().GetBool("owned")
+		optLimit, _ := cmd.Flags().GetInt("limit")
+		opt := &gitlab.ListGroupsOptions{
+			Search:      gitlab.String(optSearchString),
+			Owned:       gitlab.Bool(optOwned),
+			ListOptions: gitlab.ListOptions{Page: 1, PerPage: optLimit}}
+
+		groups, _, err := gitlabClient.Groups.ListGroups(opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printGroups(groups)
+	},
+}
+
+// showGroupCmd represents the show group command
+var showGroupCmd = &cobra.Command{
+	Use:   "show GROUP",
+	Short: "Show the details of a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		group, _, err := gitlabClient.Groups.GetGroup(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(group)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"ID:", group.ID})
+		tw.AppendRow(table.Row{"Name:", group.Name})
+		tw.AppendRow(table.Row{"Path:", group.FullPath})
+		tw.AppendRow(table.Row{"Description:", group.Description})
+		tw.AppendRow(table.Row{"Visibility:", group.Visibility})
+		tw.AppendRow(table.Row{"Parent ID:", group.ParentID})
+		tw.AppendRow(table.Row{"LFS enabled:", group.LFSEnabled})
+		tw.AppendRow(table.Row{"Request access enabled:", group.RequestAccessEnabled})
+		tw.AppendRow(table.Row{"Web URL:", group.WebURL})
+		fmt.Println(tw.Render())
+	},
+}
+
+// groupProjectsCmd represents the list group projects command
+var groupProjectsCmd = &cobra.Command{
+	Use:   "projects GROUP",
+	Short: "List the projects of a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		recursive, _ := cmd.Flags().GetBool("recursive")
+
+		projects, err := gitlabClient.ListAllGroupProjects(args[0], recursive)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(projects)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "Name", "Path"})
+		for _, project := range projects {
+			tw.AppendRow(table.Row{project.ID, project.Name, project.PathWithNamespace})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// groupSubgroupsCmd represents the list subgroups command
+var groupSubgroupsCmd = &cobra.Command{
+	Use:   "subgroups GROUP",
+	Short: "List the subgroups of a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		groups, err := gitlabClient.ListAllSubgroups(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printGroups(groups)
+	},
+}
+
+// groupMembersCmd represents the list group members command
+var groupMembersCmd = &cobra.Command{
+	Use:   "members GROUP",
+	Short: "List the members of a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		members, err := gitlabClient.ListMembers(&client.Owner{Group: args[0]})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printMembers(members)
+	},
+}
+
+// createGroupCmd represents the create group command
+var createGroupCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		path, _ := cmd.Flags().GetString("path")
+		parent, _ := cmd.Flags().GetString("parent")
+		description, _ := cmd.Flags().GetString("description")
+		visibility, _ := cmd.Flags().GetString("visibility")
+
+		if path == "" {
+			path = args[0]
+		}
+
+		opt := &gitlab.CreateGroupOptions{
+			Name:       gitlab.String(args[0]),
+			Path:       gitlab.String(path),
+			Visibility: gitlab.Visibility(gitlab.VisibilityValue(visibility)),
+		}
+		if description != "" {
+			opt.Description = gitlab.String(description)
+		}
+		if parent != "" {
+			parentGroup, _, err := gitlabClient.Groups.GetGroup(parent)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.ParentID = gitlab.Int(parentGroup.ID)
+		}
+
+		group, _, err := gitlabClient.Groups.CreateGroup(opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Group %s created: %s\n", group.FullPath, group.WebURL)
+	},
+}
+
+// groupVariablesCmd represents the list group variables command
+var groupVariablesCmd = &cobra.Command{
+	Use:   "variables GROUP",
+	Short: "List the CI/CD variables of a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		variables, err := gitlabClient.ListCIVariables(&client.Owner{Group: args[0]})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(variables)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"KEY", "TYPE", "PROTECTED", "MASKED"})
+		for _, variable := range variables {
+			tw.AppendRow(table.Row{variable.Key, variable.VariableType, variable.Protected, variable.Masked})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// printGroups prints the given groups
+func printGroups(groups []*gitlab.Group) {
+	if outputJSON() {
+		util.PrintJSON(groups)
+		return
+	}
+
+	tw := util.NewTableWriter()
+	tw.AppendHeader(table.Row{"ID", "Name", "Path", "Visibility"})
+	for _, group := range groups {
+		tw.AppendRow(table.Row{group.ID, group.Name, group.FullPath, group.Visibility})
+	}
+	fmt.Println(tw.Render())
+}
+
+// cloneGroupCmd represents the clone group command
+var cloneGroupCmd = &cobra.Command{
+	Use:   "clone GROUP [DIR]",
+	Short: "Clone the repositories of a group",
+	Long: `Clone the repositories of a group
+
+The repositories are cloned into a directory tree mirroring the group hierarchy.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		update, _ := cmd.Flags().GetBool("update")
+		workers, _ := cmd.Flags().GetInt("workers")
+		protocol := gitProtocol(cmd)
+
+		group, _, err := gitlabClient.Groups.GetGroup(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		dir := group.Path
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		projects, err := gitlabClient.ListAllGroupProjects(args[0], recursive)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		errs := make([]error, len(projects))
+		util.ForEach(len(projects), workers, func(i int) {
+			project := projects[i]
+			relPath := strings.TrimPrefix(project.PathWithNamespace, group.FullPath+"/")
+			projectDir := filepath.Join(dir, filepath.FromSlash(relPath))
+
+			if err := os.MkdirAll(filepath.Dir(projectDir), 0755); err != nil {
+				errs[i] = err
+				return
+			}
+
+			_, errs[i] = gitCloneOrUpdate(projectCloneURL(project, protocol), projectDir, update)
+			if errs[i] == nil {
+				fmt.Printf("%s done\n", projectDir)
+			}
+		})
+
+		failures, skipped := 0, 0
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"PROJECT", "RESULT"})
+		for i, project := range projects {
+			result := "ok"
+			switch {
+			case errs[i] == errAlreadyCloned:
+				skipped++
+				result = "skipped: already cloned, use --update to pull it"
+			case errs[i] != nil:
+				failures++
+				result = errs[i].Error()
+			}
+			tw.AppendRow(table.Row{project.PathWithNamespace, result})
+		}
+		fmt.Println(tw.Render())
+
+		fmt.Printf("\n%d succeeded, %d skipped, %d failed\n", len(projects)-failures-skipped, skipped, failures)
+		if failures > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+	groupCmd.AddCommand(listGroupsCmd)
+	groupCmd.AddCommand(showGroupCmd)
+	groupCmd.AddCommand(groupProjectsCmd)
+	groupCmd.AddCommand(groupSubgroupsCmd)
+	groupCmd.AddCommand(groupMembersCmd)
+	groupCmd.AddCommand(createGroupCmd)
+	groupCmd.AddCommand(groupVariablesCmd)
+	groupCmd.AddCommand(cloneGroupCmd)
+
+	listGroupsCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+	listGroupsCmd.Flags().String("search", "", "Search a group")
+	listGroupsCmd.Flags().Bool("owned", false, "List only the groups owned by the user")
+
+	groupProjectsCmd.Flags().BoolP("recursive", "r", false, "List also the projects of the subgroups")
+
+	createGroupCmd.Flags().String("path", "", "Set the group path. The default value is the name")
+	createGroupCmd.Flags().String("parent", "", "Set the parent group path or ID to create a subgroup")
+	createGroupCmd.Flags().StringP("description", "d", "", "Set the group description")
+	createGroupCmd.Flags().String("visibility", "private", "Set the group visibility: private, internal or public")
+
+	cloneGroupCmd.Flags().BoolP("recursive", "r", false, "Clone also the projects of the subgroups")
+	cloneGroupCmd.Flags().BoolP("update", "u", false, "Pull the repositories already cloned")
+	cloneGroupCmd.Flags().String("protocol", "ssh", "Set the clone protocol: ssh or https")
+	cloneGroupCmd.Flags().Int("workers", DefaultWorkers, "Set the number of concurrent clones")
+}
@@ -0,0 +1,468 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+const (
+	// IssueTemplatesDir the directory of the issue templates
+	IssueTemplatesDir = ".gitlab/issue_templates"
+)
+
+// issueCmd represents the issues command
+var issueCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "Manage issues",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listIssuesCmd represents the list issues command
+var listIssuesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the issues of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		state, _ := cmd.Flags().GetString("state")
+		milestone, _ := cmd.Flags().GetString("milestone")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		search, _ := cmd.Flags().GetString("search")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListProjectIssuesOptions{
+			State:       gitlab.String(state),
+			ListOptions: gitlab.ListOptions{Page: 1, PerPage: limit},
+		}
+		if milestone != "" {
+			opt.Milestone = gitlab.String(milestone)
+		}
+		if len(labels) > 0 {
+			opt.Labels = gitlab.Labels(labels)
+		}
+		if assignee != "" {
+			assigneeID, err := gitlabClient.GetUserID(assignee)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.AssigneeID = gitlab.Int(assigneeID)
+		}
+		if search != "" {
+			opt.Search = gitlab.String(search)
+		}
+
+		issues, _, err := gitlabClient.Issues.ListProjectIssues(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(issues)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"IID", "TITLE", "STATE", "ASSIGNEES", "LABELS", "MILESTONE"})
+		for _, issue := range issues {
+			milestone := ""
+			if issue.Milestone != nil {
+				milestone = issue.Milestone.Title
+			}
+			tw.AppendRow(table.Row{issue.IID, issue.Title, issue.State, issueAssignees(issue), strings.Join(issue.Labels, ", "), milestone})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showIssueCmd represents the show issue command
+var showIssueCmd = &cobra.Command{
+	Use:   "show IID",
+	Short: "Show the details of an issue",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		iid := parseIntArg(args[0], "issue IID")
+
+		issue, _, err := gitlabClient.Issues.GetIssue(project, iid)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(issue)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"IID:", issue.IID})
+		tw.AppendRow(table.Row{"Title:", issue.Title})
+		tw.AppendRow(table.Row{"State:", issue.State})
+		tw.AppendRow(table.Row{"Author:", issue.Author.Username})
+		tw.AppendRow(table.Row{"Assignees:", issueAssignees(issue)})
+		tw.AppendRow(table.Row{"Labels:", strings.Join(issue.Labels, ", ")})
+		if issue.Milestone != nil {
+			tw.AppendRow(table.Row{"Milestone:", issue.Milestone.Title})
+		}
+		tw.AppendRow(table.Row{"Created at:", issue.CreatedAt})
+		tw.AppendRow(table.Row{"Updated at:", issue.UpdatedAt})
+		tw.AppendRow(table.Row{"URL:", issue.WebURL})
+		fmt.Println(tw.Render())
+
+		if issue.Description != "" {
+			fmt.Printf("\n%s\n", issue.Description)
+		}
+	},
+}
+
+// createIssueCmd represents the create issue command
+var createIssueCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an issue",
+	Long: `Create an issue
+
+When the title is not given the $EDITOR is opened, optionally on an issue template:
+the first line is the title and the rest is the description.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		template, _ := cmd.Flags().GetString("template")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		assignees, _ := cmd.Flags().GetStringSlice("assignees")
+		milestone, _ := cmd.Flags().GetInt("milestone")
+
+		if title == "" {
+			text := "\n"
+			if template != "" {
+				content, err := readIssueTemplate(gitlabClient, project, template)
+				if err != nil {
+					log.Fatal(err)
+				}
+				text = "\n\n" + content
+			}
+
+			edited, err := util.EditText(text, "ISSUE_*.md")
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			lines := strings.SplitN(edited, "\n", 2)
+			title = strings.TrimSpace(lines[0])
+			if len(lines) > 1 {
+				description = strings.TrimSpace(lines[1])
+			}
+			if title == "" {
+				log.Fatal("aborted: empty title")
+			}
+		}
+
+		opt := &gitlab.CreateIssueOptions{
+			Title:       gitlab.String(title),
+			Description: gitlab.String(description),
+		}
+		if len(labels) > 0 {
+			issueLabels := gitlab.Labels(labels)
+			opt.Labels = &issueLabels
+		}
+		if len(assignees) > 0 {
+			assigneeIDs, err := getUserIDs(gitlabClient, assignees)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.AssigneeIDs = assigneeIDs
+		}
+		if milestone != -1 {
+			opt.MilestoneID = gitlab.Int(milestone)
+		}
+
+		issue, _, err := gitlabClient.Issues.CreateIssue(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Issue #%d created: %s\n", issue.IID, issue.WebURL)
+	},
+}
+
+// closeIssueCmd represents the close issue command
+var closeIssueCmd = &cobra.Command{
+	Use:   "close IID",
+	Short: "Close an issue",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateIssue(cmd, args, &gitlab.UpdateIssueOptions{StateEvent: gitlab.String("close")})
+		fmt.Printf("Issue #%s closed\n", args[0])
+	},
+}
+
+// reopenIssueCmd represents the reopen issue command
+var reopenIssueCmd = &cobra.Command{
+	Use:   "reopen IID",
+	Short: "Reopen an issue",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateIssue(cmd, args, &gitlab.UpdateIssueOptions{StateEvent: gitlab.String("reopen")})
+		fmt.Printf("Issue #%s reopened\n", args[0])
+	},
+}
+
+// commentIssueCmd represents the comment issue command
+var commentIssueCmd = &cobra.Command{
+	Use:   "comment IID [BODY]",
+	Short: "Comment an issue",
+	Long: `Comment an issue
+
+When the BODY is not given the $EDITOR is opened.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		iid := parseIntArg(args[0], "issue IID")
+
+		var body string
+		if len(args) > 1 {
+			body = args[1]
+		} else {
+			edited, err := util.EditText("", "COMMENT_*.md")
+			if err != nil {
+				log.Fatal(err)
+			}
+			body = strings.TrimSpace(edited)
+		}
+		if body == "" {
+			log.Fatal("aborted: empty comment")
+		}
+
+		opt := &gitlab.CreateIssueNoteOptions{Body: gitlab.String(body)}
+		_, _, err := gitlabClient.Notes.CreateIssueNote(project, iid, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Issue #%d commented\n", iid)
+	},
+}
+
+// assignIssueCmd represents the assign issue command
+var assignIssueCmd = &cobra.Command{
+	Use:   "assign IID USERNAME...",
+	Short: "Assign an issue to one or more users",
+	Long:  ``,
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		assigneeIDs, err := getUserIDs(gitlabClient, args[1:])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		updateIssue(cmd, args, &gitlab.UpdateIssueOptions{AssigneeIDs: assigneeIDs})
+		fmt.Printf("Issue #%s assigned to %s\n", args[0], strings.Join(args[1:], ", "))
+	},
+}
+
+// labelIssueCmd represents the label issue command
+var labelIssueCmd = &cobra.Command{
+	Use:   "label IID",
+	Short: "Add or remove the labels of an issue",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		add, _ := cmd.Flags().GetStringSlice("add")
+		remove, _ := cmd.Flags().GetStringSlice("remove")
+		iid := parseIntArg(args[0], "issue IID")
+
+		if len(add) == 0 && len(remove) == 0 {
+			log.Fatal("requires the --add or the --remove flag")
+		}
+
+		issue, _, err := gitlabClient.Issues.GetIssue(project, iid)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// the whole label set is sent, since the update options lack the
+		// fields to add or remove single labels
+		labels := mergeLabels(issue.Labels, add, remove)
+		updateIssue(cmd, args, &gitlab.UpdateIssueOptions{Labels: &labels})
+		fmt.Printf("Issue #%s labels updated\n", args[0])
+	},
+}
+
+// updateIssue applies the update options to the issue
+func updateIssue(cmd *cobra.Command, args []string, opt *gitlab.UpdateIssueOptions) {
+	gitlabClient := client.GetClient()
+
+	project, _ := cmd.Flags().GetString("project")
+	iid := parseIntArg(args[0], "issue IID")
+
+	_, _, err := gitlabClient.Issues.UpdateIssue(project, iid, opt)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// mergeLabels returns the labels with the added labels appended and the removed ones dropped
+func mergeLabels(labels gitlab.Labels, add, remove []string) gitlab.Labels {
+	removed := make(map[string]bool)
+	for _, label := range remove {
+		removed[label] = true
+	}
+
+	merged := gitlab.Labels{}
+	seen := make(map[string]bool)
+	for _, label := range append(append([]string{}, labels...), add...) {
+		if !removed[label] && !seen[label] {
+			merged = append(merged, label)
+			seen[label] = true
+		}
+	}
+	return merged
+}
+
+// readIssueTemplate returns the content of the issue template from the local
+// working copy, when the current directory is a clone of the project, or
+// otherwise from the default branch of the project repository
+func readIssueTemplate(gitlabClient *client.Client, pid, name string) (string, error) {
+	fileName := IssueTemplatesDir + "/" + name + ".md"
+
+	project, _, err := gitlabClient.Projects.GetProject(pid, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if isProjectWorkingCopy(project) {
+		if topLevel, err := util.Git("rev-parse", "--show-toplevel"); err == nil {
+			if content, err := ioutil.ReadFile(filepath.Join(topLevel, filepath.FromSlash(fileName))); err == nil {
+				return string(content), nil
+			}
+		}
+	}
+
+	opt := &gitlab.GetRawFileOptions{Ref: gitlab.String(project.DefaultBranch)}
+	content, _, err := gitlabClient.RepositoryFiles.GetRawFile(pid, fileName, opt)
+	if err != nil {
+		return "", fmt.Errorf("issue template %q not found: %v", name, err)
+	}
+	return string(content), nil
+}
+
+// isProjectWorkingCopy returns true if a remote of the repository in the current directory is the project
+func isProjectWorkingCopy(project *gitlab.Project) bool {
+	urls, err := util.RemoteURLs()
+	if err != nil {
+		return false
+	}
+
+	for _, u := range urls {
+		u = normalizeRepoURL(u)
+		if u == normalizeRepoURL(project.SSHURLToRepo) || u == normalizeRepoURL(project.HTTPURLToRepo) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRepoURL strips the credentials and the .git suffix from a repository URL
+func normalizeRepoURL(repoURL string) string {
+	if u, err := url.Parse(repoURL); err == nil && u.User != nil && (u.Scheme == "http" || u.Scheme == "https") {
+		u.User = nil
+		repoURL = u.String()
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+}
+
+// issueAssignees returns the usernames of the issue assignees
+func issueAssignees(issue *gitlab.Issue) string {
+	var usernames []string
+	for _, assignee := range issue.Assignees {
+		usernames = append(usernames, assignee.Username)
+	}
+	return strings.Join(usernames, ", ")
+}
+
+// getUserIDs returns the ids of the users with the given usernames
+func getUserIDs(gitlabClient *client.Client, usernames []string) ([]int, error) {
+	var ids []int
+	for _, username := range usernames {
+		id, err := gitlabClient.GetUserID(username)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func init() {
+	rootCmd.AddCommand(issueCmd)
+	issueCmd.AddCommand(listIssuesCmd)
+	issueCmd.AddCommand(showIssueCmd)
+	issueCmd.AddCommand(createIssueCmd)
+	issueCmd.AddCommand(closeIssueCmd)
+	issueCmd.AddCommand(reopenIssueCmd)
+	issueCmd.AddCommand(commentIssueCmd)
+	issueCmd.AddCommand(assignIssueCmd)
+	issueCmd.AddCommand(labelIssueCmd)
+
+	issueCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(issueCmd.PersistentFlags(), "project")
+
+	listIssuesCmd.Flags().StringP("state", "s", "opened", "Filter by state: opened, closed or all")
+	listIssuesCmd.Flags().StringP("milestone", "m", "", "Filter by milestone title")
+	listIssuesCmd.Flags().StringSlice("labels", []string{}, "Filter by labels")
+	listIssuesCmd.Flags().String("assignee", "", "Filter by assignee username")
+	listIssuesCmd.Flags().String("search", "", "Search in title and description")
+	listIssuesCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	createIssueCmd.Flags().String("title", "", "Set the title. When missing the $EDITOR is opened")
+	createIssueCmd.Flags().StringP("description", "d", "", "Set the description")
+	createIssueCmd.Flags().StringP("template", "t", "", "Set the template from "+IssueTemplatesDir+" to edit")
+	createIssueCmd.Flags().StringSlice("labels", []string{}, "Set the labels")
+	createIssueCmd.Flags().StringSlice("assignees", []string{}, "Set the assignee usernames")
+	createIssueCmd.Flags().IntP("milestone", "m", -1, "Set the milestone id")
+
+	labelIssueCmd.Flags().StringSlice("add", []string{}, "Add the labels")
+	labelIssueCmd.Flags().StringSlice("remove", []string{}, "Remove the labels")
+}
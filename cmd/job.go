@@ -16,13 +16,21 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/mosteroid/gitlabctl/client"
 	"github.com/mosteroid/gitlabctl/util"
 	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
 )
 
 // jobsCmd represents the pipelines command
@@ -35,18 +43,174 @@ var jobsCmd = &cobra.Command{
 
 //jobTraceCmd represents the trace job command
 var jobTraceCmd = &cobra.Command{
-	Use:   "trace",
+	Use:   "trace [JOB_NAME]",
 	Short: "Show a job trace",
 	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		gitlabClient := client.GetClient()
 
 		project, _ := cmd.Flags().GetString("project")
-		job, _ := cmd.Flags().GetInt("job")
 
-		traceFile, _, _ := gitlabClient.Jobs.GetTraceFile(project, job)
+		jobs, err := selectJobs(cmd, args, "trace")
+		if err != nil {
+			log.Fatal(err)
+		}
 
-		fmt.Print(traceFile)
+		for _, job := range jobs {
+			if len(jobs) > 1 {
+				fmt.Printf("\n==> %d) %s <==\n", job.ID, job.Name)
+			}
+
+			traceFile, _, err := gitlabClient.Jobs.GetTraceFile(project, job.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			io.Copy(os.Stdout, traceFile)
+		}
+	},
+}
+
+// showJobCmd represents the show job command
+var showJobCmd = &cobra.Command{
+	Use:   "show JOB_ID",
+	Short: "Show the details of a job",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		jobID := parseIntArg(args[0], "job id")
+
+		job, err := gitlabClient.GetJob(project, jobID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(job)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"ID:", job.ID})
+		tw.AppendRow(table.Row{"Name:", job.Name})
+		tw.AppendRow(table.Row{"Status:", sw.Sprintf(job.Status)})
+		tw.AppendRow(table.Row{"Stage:", job.Stage})
+		tw.AppendRow(table.Row{"Ref:", job.Ref})
+		tw.AppendRow(table.Row{"Tag:", job.Tag})
+		if job.Commit != nil {
+			tw.AppendRow(table.Row{"Commit:", fmt.Sprintf("%s %s", job.Commit.ShortID, job.Commit.Title)})
+		}
+		tw.AppendRow(table.Row{"Pipeline:", job.Pipeline.ID})
+		if job.Runner.ID != 0 {
+			tw.AppendRow(table.Row{"Runner:", fmt.Sprintf("%d) %s", job.Runner.ID, job.Runner.Description)})
+		}
+		if job.User != nil {
+			tw.AppendRow(table.Row{"User:", job.User.Username})
+		}
+		tw.AppendRow(table.Row{"Tags:", strings.Join(job.TagList, ", ")})
+		tw.AppendRow(table.Row{"Created at:", job.CreatedAt})
+		tw.AppendRow(table.Row{"Started at:", job.StartedAt})
+		tw.AppendRow(table.Row{"Finished at:", job.FinishedAt})
+		if job.CreatedAt != nil && job.StartedAt != nil {
+			tw.AppendRow(table.Row{"Queued:", job.StartedAt.Sub(*job.CreatedAt).Round(time.Second)})
+		}
+		tw.AppendRow(table.Row{"Duration:", util.FormatTime(int64(job.Duration))})
+		tw.AppendRow(table.Row{"Coverage:", job.Coverage})
+		if job.FailureReason != "" {
+			tw.AppendRow(table.Row{"Failure reason:", job.FailureReason})
+		}
+		tw.AppendRow(table.Row{"Allow failure:", job.AllowFailure})
+		tw.AppendRow(table.Row{"Artifacts expire at:", job.ArtifactsExpireAt})
+		tw.AppendRow(table.Row{"URL:", job.WebURL})
+		fmt.Println(tw.Render())
+
+		if len(job.Artifacts) > 0 {
+			fmt.Print("\nArtifacts:\n")
+			twArtifacts := util.NewTableWriter()
+			twArtifacts.AppendHeader(table.Row{"FILENAME", "TYPE", "FORMAT", "SIZE"})
+			for _, artifact := range job.Artifacts {
+				twArtifacts.AppendRow(table.Row{artifact.Filename, artifact.FileType, artifact.FileFormat, util.FormatBytes(int64(artifact.Size))})
+			}
+			fmt.Println(twArtifacts.Render())
+		}
+	},
+}
+
+// queuedJob rapresents a pending job waiting for a runner
+type queuedJob struct {
+	*client.PendingJob
+	MatchingRunners int `json:"matching_runners"`
+}
+
+// queueJobsCmd represents the job queue command
+var queueJobsCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List the pending jobs and the online runners able to pick them",
+	Long: `List the pending jobs and the online runners able to pick them
+
+Lists the pending jobs of a project or of all the projects of a group, from the longest queued.
+A job can be picked by the active online runners having all the job tags, or by the
+runners allowed to run untagged jobs if the job has no tags. The runners limited to the
+protected refs pick only the jobs of protected branches and tags, and the project runners
+only the jobs of the projects they are assigned to. The runners considered are the ones
+available to the project or to the group; the runners whose details cannot be read are
+reported and skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		owner := ownerFromFlags(cmd)
+
+		pending, err := gitlabClient.ListPendingJobs(owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		runners, err := gitlabClient.ListRunners(owner, false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		details, errs := gitlabClient.ListRunnerDetails(runners)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Runner skipped, details not read: %v\n", err)
+		}
+
+		var queue []*queuedJob
+		stuck := 0
+		for _, job := range pending {
+			queued := &queuedJob{PendingJob: job}
+			for _, runner := range details {
+				if client.RunnerCanPickJob(runner, job) {
+					queued.MatchingRunners++
+				}
+			}
+			if queued.MatchingRunners == 0 {
+				stuck++
+			}
+			queue = append(queue, queued)
+		}
+		sort.SliceStable(queue, func(i, j int) bool {
+			return queue[i].QueuedDuration > queue[j].QueuedDuration
+		})
+
+		if outputJSON() {
+			util.PrintJSON(queue)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "NAME", "REF", "QUEUED", "TAGS", "ONLINE RUNNERS", "URL"})
+		for _, job := range queue {
+			tw.AppendRow(table.Row{job.ID, job.Name, job.Ref, util.FormatTime(int64(job.QueuedDuration)), strings.Join(client.JobTags(&job.Job), ", "), job.MatchingRunners, job.WebURL})
+		}
+		fmt.Println(tw.Render())
+
+		fmt.Printf("\n%d pending jobs, %d without any matching online runner\n", len(queue), stuck)
 	},
 }
 
@@ -74,78 +238,408 @@ var jobStatsCmd = &cobra.Command{
 
 // retryJobCmd represents the retry job command
 var retryJobCmd = &cobra.Command{
-	Use:   "retry",
+	Use:   "retry [JOB_NAME]",
 	Short: "Retry a job",
 	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		gitlabClient := client.GetClient()
 
 		project, _ := cmd.Flags().GetString("project")
-		jobID, _ := cmd.Flags().GetInt("job")
-
-		_, _, err := gitlabClient.Jobs.RetryJob(project, jobID)
 
+		jobs, err := selectJobs(cmd, args, "retry")
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		fmt.Printf("Job restarted")
+		for _, job := range jobs {
+			_, _, err := gitlabClient.Jobs.RetryJob(project, job.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Job %d) %s restarted\n", job.ID, job.Name)
+		}
 	},
 }
 
 // cancelJobCmd represents the cancel job command
 var cancelJobCmd = &cobra.Command{
-	Use:   "cancel",
+	Use:   "cancel [JOB_NAME]",
 	Short: "Cancel a job",
 	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		gitlabClient := client.GetClient()
 
 		project, _ := cmd.Flags().GetString("project")
-		jobID, _ := cmd.Flags().GetInt("job")
-
-		_, _, err := gitlabClient.Jobs.CancelJob(project, jobID)
 
+		jobs, err := selectJobs(cmd, args, "cancel")
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		fmt.Printf("Job cancelled")
+		for _, job := range jobs {
+			_, _, err := gitlabClient.Jobs.CancelJob(project, job.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Job %d) %s cancelled\n", job.ID, job.Name)
+		}
 	},
 }
 
-// runJobCmd represents the cancel job command
+// runJobCmd represents the run job command
 var runJobCmd = &cobra.Command{
-	Use:   "run",
+	Use:   "run [JOB_NAME]",
 	Short: "Run a job",
 	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		gitlabClient := client.GetClient()
 
 		project, _ := cmd.Flags().GetString("project")
-		jobID, _ := cmd.Flags().GetInt("job")
 
-		_, _, err := gitlabClient.Jobs.PlayJob(project, jobID)
+		vars, _ := cmd.Flags().GetStringArray("var")
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		variables, err := parseJobVariables(vars)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		jobs, err := selectJobs(cmd, args, "run")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		failed := false
+		for _, job := range jobs {
+			job, err := gitlabClient.PlayJobWithVariables(project, job.ID, variables)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Job %d) %s started\n", job.ID, job.Name)
+
+			if wait {
+				job, err = waitJob(gitlabClient, project, job)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				sw := util.NewStatusWriter()
+				fmt.Printf("\nThe job %d exited with status: %s \n", job.ID, sw.Sprintf(job.Status))
+				failed = failed || job.Status != "success"
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// eraseJobCmd represents the erase job command
+var eraseJobCmd = &cobra.Command{
+	Use:   "erase [JOB_NAME]",
+	Short: "Erase a job trace and artifacts",
+	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
 
+		project, _ := cmd.Flags().GetString("project")
+
+		jobs, err := selectJobs(cmd, args, "erase")
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		fmt.Printf("Job started")
+		for _, job := range jobs {
+			_, _, err := gitlabClient.Jobs.EraseJob(project, job.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Job %d) %s erased\n", job.ID, job.Name)
+		}
 	},
 }
 
+// jobArtifactsCmd represents the job artifacts command
+var jobArtifactsCmd = &cobra.Command{
+	Use:   "artifacts",
+	Short: "Manage job artifacts",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// keepJobArtifactsCmd represents the keep job artifacts command
+var keepJobArtifactsCmd = &cobra.Command{
+	Use:   "keep [JOB_NAME]",
+	Short: "Prevent the artifacts of a job from expiring",
+	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		jobs, err := selectJobs(cmd, args, "keep the artifacts of")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, job := range jobs {
+			_, _, err := gitlabClient.Jobs.KeepArtifacts(project, job.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Job %d) %s artifacts kept\n", job.ID, job.Name)
+		}
+	},
+}
+
+// deleteJobArtifactsCmd represents the delete job artifacts command
+var deleteJobArtifactsCmd = &cobra.Command{
+	Use:   "delete [JOB_NAME]",
+	Short: "Delete the artifacts of a job",
+	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		jobs, err := selectJobs(cmd, args, "delete the artifacts of")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, job := range jobs {
+			err := gitlabClient.DeleteJobArtifacts(project, job.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Job %d) %s artifacts deleted\n", job.ID, job.Name)
+		}
+	},
+}
+
+// pruneJobArtifactsCmd represents the prune job artifacts command
+var pruneJobArtifactsCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete the artifacts of the project jobs older than a given age",
+	Long: `Delete the artifacts of the project jobs older than a given age
+
+The artifacts without an expiration date, such as the ones kept explicitly, are deleted
+only with the --include-never-expiring flag.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		includeNeverExpiring, _ := cmd.Flags().GetBool("include-never-expiring")
+
+		age, err := util.ParseDuration(olderThan)
+		if err != nil {
+			log.Fatal(err)
+		}
+		limit := time.Now().Add(-age)
+
+		jobs, err := gitlabClient.ListAllProjectJobs(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var selected []*gitlab.Job
+		reclaimed := int64(0)
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "NAME", "REF", "CREATED AT", "SIZE"})
+		for i := range jobs {
+			job := &jobs[i]
+			size := client.GetJobArtifactsSize(job)
+			if size == 0 || job.CreatedAt == nil || job.CreatedAt.After(limit) {
+				continue
+			}
+			// artifacts without an expiration date were kept explicitly or never expire
+			if job.ArtifactsExpireAt == nil && !includeNeverExpiring {
+				continue
+			}
+
+			selected = append(selected, job)
+			reclaimed += size
+			tw.AppendRow(table.Row{job.ID, job.Name, job.Ref, job.CreatedAt, util.FormatBytes(size)})
+		}
+
+		if len(selected) == 0 {
+			fmt.Println("No artifacts to delete")
+			return
+		}
+		fmt.Println(tw.Render())
+
+		if dryRun {
+			fmt.Printf("\n%s would be reclaimed\n", util.FormatBytes(reclaimed))
+			return
+		}
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete the artifacts of %d jobs?", len(selected))) {
+			log.Fatal("aborted")
+		}
+
+		reclaimed = 0
+		failed := 0
+		for _, job := range selected {
+			if err := gitlabClient.DeleteJobArtifacts(project, job.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "Job %d) %s artifacts not deleted: %v\n", job.ID, job.Name, err)
+				failed++
+				continue
+			}
+			reclaimed += client.GetJobArtifactsSize(job)
+		}
+
+		fmt.Printf("\nArtifacts of %d jobs deleted, %d failed, %s reclaimed\n", len(selected)-failed, failed, util.FormatBytes(reclaimed))
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// parseJobVariables parses a list of KEY=VALUE job variables
+func parseJobVariables(vars []string) ([]*client.JobVariable, error) {
+	var variables []*client.JobVariable
+	for _, v := range vars {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid variable %q: must be in the KEY=VALUE format", v)
+		}
+		variables = append(variables, &client.JobVariable{Key: kv[0], Value: kv[1]})
+	}
+	return variables, nil
+}
+
+// waitJob blocks until the job finishes, streaming its trace to the standard output
+func waitJob(gitlabClient *client.Client, pid string, job *gitlab.Job) (*gitlab.Job, error) {
+	offset := 0
+	for {
+		job, _, err := gitlabClient.Jobs.GetJob(pid, job.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		traceFile, _, err := gitlabClient.Jobs.GetTraceFile(pid, job.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		trace, _ := ioutil.ReadAll(traceFile)
+		if len(trace) > offset {
+			os.Stdout.Write(trace[offset:])
+			offset = len(trace)
+		}
+
+		if client.IsJobFinished(job) {
+			return job, nil
+		}
+
+		time.Sleep(WatchUpdateSleep)
+	}
+}
+
+// selectJobs returns the jobs targeted by a job command. The jobs are
+// identified either by the --job flag or by a name, with glob support,
+// within the pipeline selected by the --pipeline and --ref flags.
+// When more than one job matches the user is asked to confirm the action.
+func selectJobs(cmd *cobra.Command, args []string, action string) ([]*gitlab.Job, error) {
+	gitlabClient := client.GetClient()
+
+	project, _ := cmd.Flags().GetString("project")
+	jobID, _ := cmd.Flags().GetInt("job")
+
+	if jobID != -1 {
+		job, _, err := gitlabClient.Jobs.GetJob(project, jobID)
+		if err != nil {
+			return nil, err
+		}
+		return []*gitlab.Job{job}, nil
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("requires a JOB_NAME argument or the --job flag")
+	}
+
+	pipeline, _ := cmd.Flags().GetString("pipeline")
+	ref, _ := cmd.Flags().GetString("ref")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	pipelineID, err := gitlabClient.ResolvePipelineID(project, pipeline, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := gitlabClient.FindPipelineJobs(project, pipelineID, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no job matching %q found in pipeline %d", args[0], pipelineID)
+	}
+
+	if len(jobs) > 1 && !yes {
+		fmt.Printf("The following jobs of pipeline %d match %q:\n", pipelineID, args[0])
+		for _, job := range jobs {
+			fmt.Printf("  %d) %s [%s]\n", job.ID, job.Name, job.Status)
+		}
+		if !util.Confirm(fmt.Sprintf("Do you want to %s %d jobs?", action, len(jobs))) {
+			return nil, errors.New("aborted")
+		}
+	}
+
+	return jobs, nil
+}
+
 func init() {
 	rootCmd.AddCommand(jobsCmd)
 	jobsCmd.AddCommand(jobStatsCmd)
+	jobsCmd.AddCommand(queueJobsCmd)
+	jobsCmd.AddCommand(showJobCmd)
 	jobsCmd.AddCommand(jobTraceCmd)
 	jobsCmd.AddCommand(retryJobCmd)
 	jobsCmd.AddCommand(cancelJobCmd)
 	jobsCmd.AddCommand(runJobCmd)
+	jobsCmd.AddCommand(eraseJobCmd)
+	jobsCmd.AddCommand(jobArtifactsCmd)
+	jobArtifactsCmd.AddCommand(keepJobArtifactsCmd)
+	jobArtifactsCmd.AddCommand(deleteJobArtifactsCmd)
+	jobArtifactsCmd.AddCommand(pruneJobArtifactsCmd)
 
 	jobsCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
 	cobra.MarkFlagRequired(jobsCmd.PersistentFlags(), "project")
 
 	jobsCmd.PersistentFlags().IntP("job", "j", -1, "Set the job id")
-	cobra.MarkFlagRequired(jobsCmd.Flags(), "job")
+
+	// the local flags shadow the required --project flag so that a group can be set instead
+	queueJobsCmd.Flags().StringP("project", "p", "", "Set the project name or project ID")
+	queueJobsCmd.Flags().StringP("group", "g", "", "Set the group path or group ID")
+
+	for _, c := range []*cobra.Command{jobTraceCmd, retryJobCmd, cancelJobCmd, runJobCmd, eraseJobCmd, keepJobArtifactsCmd, deleteJobArtifactsCmd} {
+		c.Flags().StringP("pipeline", "l", client.LatestPipeline, "Set the pipeline id used to resolve the job name, or \"latest\"")
+		c.Flags().StringP("ref", "r", "", "Set the ref used to resolve the latest pipeline")
+		c.Flags().BoolP("yes", "y", false, "Do not ask for confirmation when more than one job matches")
+	}
+
+	runJobCmd.Flags().StringArray("var", []string{}, "Set a job variable in the KEY=VALUE format. Can be repeated")
+	runJobCmd.Flags().BoolP("wait", "w", false, "Wait for the job to finish streaming its trace")
+
+	pruneJobArtifactsCmd.Flags().String("older-than", "30d", "Delete the artifacts of the jobs older than the given age, e.g. 30d")
+	pruneJobArtifactsCmd.Flags().Bool("dry-run", false, "Only report the artifacts that would be deleted")
+	pruneJobArtifactsCmd.Flags().Bool("include-never-expiring", false, "Delete also the artifacts without an expiration date, such as the kept ones")
+	pruneJobArtifactsCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
 }
@@ -0,0 +1,263 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// labelCmd represents the labels command
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Manage labels",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listLabelsCmd represents the list labels command
+var listLabelsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the labels of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		labels, err := gitlabClient.ListAllLabels(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(labels)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "COLOR", "PRIORITY", "DESCRIPTION"})
+		for _, label := range labels {
+			priority := ""
+			if label.Priority != nil {
+				priority = strconv.Itoa(*label.Priority)
+			}
+			tw.AppendRow(table.Row{label.Name, label.Color, priority, label.Description})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// createLabelCmd represents the create label command
+var createLabelCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a label",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		color, _ := cmd.Flags().GetString("color")
+		description, _ := cmd.Flags().GetString("description")
+		priority, _ := cmd.Flags().GetInt("priority")
+
+		label := &client.Label{Name: args[0], Color: color, Description: description}
+		if priority != -1 {
+			label.Priority = &priority
+		}
+
+		if err := gitlabClient.SaveLabel(project, "", label); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Label %s created\n", args[0])
+	},
+}
+
+// updateLabelCmd represents the update label command
+var updateLabelCmd = &cobra.Command{
+	Use:   "update NAME",
+	Short: "Update a label",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		newName, _ := cmd.Flags().GetString("name")
+		color, _ := cmd.Flags().GetString("color")
+		description, _ := cmd.Flags().GetString("description")
+		priority, _ := cmd.Flags().GetInt("priority")
+
+		label, err := gitlabClient.GetLabel(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if newName != "" {
+			label.Name = newName
+		}
+		if color != "" {
+			label.Color = color
+		}
+		if cmd.Flags().Changed("description") {
+			label.Description = description
+		}
+		if priority != -1 {
+			label.Priority = &priority
+		}
+
+		if err := gitlabClient.SaveLabel(project, args[0], label); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Label %s updated\n", args[0])
+	},
+}
+
+// deleteLabelCmd represents the delete label command
+var deleteLabelCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a label",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		opt := &gitlab.DeleteLabelOptions{Name: gitlab.String(args[0])}
+		if _, err := gitlabClient.Labels.DeleteLabel(project, opt); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Label %s deleted\n", args[0])
+	},
+}
+
+// syncLabelsCmd represents the sync labels command
+var syncLabelsCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Copy the labels of a project to other projects",
+	Long: `Copy the labels of a project to other projects
+
+The missing labels are created and the labels with a different color,
+description or priority are updated. The labels only in the targets are kept.
+A target ending with /* selects all the projects of a group and its subgroups.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetStringSlice("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		labels, err := gitlabClient.ListAllLabels(from)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var targets []string
+		for _, spec := range to {
+			projects, err := gitlabClient.ExpandProjects(spec)
+			if err != nil {
+				log.Fatal(err)
+			}
+			targets = append(targets, projects...)
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"PROJECT", "LABEL", "ACTION"})
+		for _, target := range targets {
+			if target == from {
+				continue
+			}
+
+			targetLabels, err := gitlabClient.ListAllLabels(target)
+			if err != nil {
+				tw.AppendRow(table.Row{target, "", fmt.Sprintf("failed: %v", err)})
+				continue
+			}
+
+			targetLabelsMap := make(map[string]*client.Label)
+			for _, label := range targetLabels {
+				targetLabelsMap[label.Name] = label
+			}
+
+			for _, label := range labels {
+				var action string
+				var err error
+				targetLabel, ok := targetLabelsMap[label.Name]
+				switch {
+				case !ok:
+					action = "create"
+					if !dryRun {
+						err = gitlabClient.SaveLabel(target, "", label)
+					}
+				case targetLabel.Color != label.Color || targetLabel.Description != label.Description || !targetLabel.SamePriority(label):
+					action = "update"
+					if !dryRun {
+						err = gitlabClient.SaveLabel(target, label.Name, label)
+					}
+				default:
+					continue
+				}
+
+				if err != nil {
+					action = fmt.Sprintf("%s failed: %v", action, err)
+				}
+				tw.AppendRow(table.Row{target, label.Name, action})
+			}
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(labelCmd)
+	labelCmd.AddCommand(listLabelsCmd)
+	labelCmd.AddCommand(createLabelCmd)
+	labelCmd.AddCommand(updateLabelCmd)
+	labelCmd.AddCommand(deleteLabelCmd)
+	labelCmd.AddCommand(syncLabelsCmd)
+
+	for _, c := range []*cobra.Command{listLabelsCmd, createLabelCmd, updateLabelCmd, deleteLabelCmd} {
+		c.Flags().StringP("project", "p", "", "Set the project name or project ID")
+		cobra.MarkFlagRequired(c.Flags(), "project")
+	}
+
+	createLabelCmd.Flags().StringP("color", "c", "#428BCA", "Set the color in the #RRGGBB format")
+	createLabelCmd.Flags().StringP("description", "d", "", "Set the description")
+	createLabelCmd.Flags().Int("priority", -1, "Set the priority. Lower values have higher priority")
+
+	updateLabelCmd.Flags().String("name", "", "Set the new name")
+	updateLabelCmd.Flags().StringP("color", "c", "", "Set the color in the #RRGGBB format")
+	updateLabelCmd.Flags().StringP("description", "d", "", "Set the description")
+	updateLabelCmd.Flags().Int("priority", -1, "Set the priority. Lower values have higher priority")
+
+	syncLabelsCmd.Flags().String("from", "", "Set the source project")
+	cobra.MarkFlagRequired(syncLabelsCmd.Flags(), "from")
+	syncLabelsCmd.Flags().StringSlice("to", []string{}, "Set the target projects, or GROUP/* for all the projects of a group")
+	cobra.MarkFlagRequired(syncLabelsCmd.Flags(), "to")
+	syncLabelsCmd.Flags().Bool("dry-run", false, "Only report the changes")
+}
@@ -0,0 +1,250 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// memberCmd represents the members command
+var memberCmd = &cobra.Command{
+	Use:   "member",
+	Short: "Manage project and group members",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listMembersCmd represents the list members command
+var listMembersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the members of a project or a group",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		members, err := gitlabClient.ListMembers(ownerFromFlags(cmd))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printMembers(members)
+	},
+}
+
+// addMemberCmd represents the add member command
+var addMemberCmd = &cobra.Command{
+	Use:   "add USERNAME",
+	Short: "Add a user to a project or a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		access, _ := cmd.Flags().GetString("access")
+		expires, _ := cmd.Flags().GetString("expires")
+
+		accessLevel, err := client.ParseAccessLevel(access)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		userID, err := gitlabClient.GetUserID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		owner := ownerFromFlags(cmd)
+		if err := gitlabClient.AddMember(owner, userID, accessLevel, expires); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("User %s added to %s as %s\n", args[0], owner, access)
+	},
+}
+
+// updateMemberCmd represents the update member command
+var updateMemberCmd = &cobra.Command{
+	Use:   "update USERNAME",
+	Short: "Update the access level of a project or group member",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		access, _ := cmd.Flags().GetString("access")
+		expires, _ := cmd.Flags().GetString("expires")
+
+		accessLevel, err := client.ParseAccessLevel(access)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		userID, err := gitlabClient.GetUserID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiry *string
+		if cmd.Flags().Changed("expires") {
+			expiry = &expires
+		}
+
+		owner := ownerFromFlags(cmd)
+		if err := gitlabClient.UpdateMember(owner, userID, accessLevel, expiry); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("User %s of %s updated to %s\n", args[0], owner, access)
+	},
+}
+
+// removeMemberCmd represents the remove member command
+var removeMemberCmd = &cobra.Command{
+	Use:   "remove USERNAME",
+	Short: "Remove a user from a project or a group",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		userID, err := gitlabClient.GetUserID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		owner := ownerFromFlags(cmd)
+		if err := gitlabClient.RemoveMember(owner, userID); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("User %s removed from %s\n", args[0], owner)
+	},
+}
+
+// syncMembersCmd represents the sync members command
+var syncMembersCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Copy the members of a project or group to another project or group",
+	Long: `Copy the members of a project or group to another project or group
+
+The missing members are added and the members with a different access level
+or expiry date are updated. The members only in the target are kept.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		source, err := gitlabClient.ResolveOwner(from)
+		if err != nil {
+			log.Fatal(err)
+		}
+		target, err := gitlabClient.ResolveOwner(to)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		sourceMembers, err := gitlabClient.ListMembers(source)
+		if err != nil {
+			log.Fatal(err)
+		}
+		targetMembers, err := gitlabClient.ListMembers(target)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		targetMembersMap := make(map[int]*client.Member)
+		for _, member := range targetMembers {
+			targetMembersMap[member.ID] = member
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"USERNAME", "ACTION", "ACCESS LEVEL", "EXPIRES AT"})
+		for _, member := range sourceMembers {
+			var action string
+			var err error
+			targetMember, ok := targetMembersMap[member.ID]
+			switch {
+			case !ok:
+				action = "add"
+				if !dryRun {
+					err = gitlabClient.AddMember(target, member.ID, member.AccessLevel, member.Expiry())
+				}
+			case targetMember.AccessLevel != member.AccessLevel || targetMember.Expiry() != member.Expiry():
+				action = "update"
+				if !dryRun {
+					err = gitlabClient.UpdateMember(target, member.ID, member.AccessLevel, gitlab.String(member.Expiry()))
+				}
+			default:
+				continue
+			}
+
+			if err != nil {
+				action = fmt.Sprintf("%s failed: %v", action, err)
+			}
+			tw.AppendRow(table.Row{member.Username, action, client.AccessLevelName(member.AccessLevel), member.Expiry()})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// printMembers prints the given members
+func printMembers(members []*client.Member) {
+	if outputJSON() {
+		util.PrintJSON(members)
+		return
+	}
+
+	tw := util.NewTableWriter()
+	tw.AppendHeader(table.Row{"ID", "USERNAME", "NAME", "STATE", "ACCESS LEVEL", "EXPIRES AT"})
+	for _, member := range members {
+		tw.AppendRow(table.Row{member.ID, member.Username, member.Name, member.State, client.AccessLevelName(member.AccessLevel), member.Expiry()})
+	}
+	fmt.Println(tw.Render())
+}
+
+func init() {
+	rootCmd.AddCommand(memberCmd)
+	memberCmd.AddCommand(listMembersCmd)
+	memberCmd.AddCommand(addMemberCmd)
+	memberCmd.AddCommand(updateMemberCmd)
+	memberCmd.AddCommand(removeMemberCmd)
+	memberCmd.AddCommand(syncMembersCmd)
+
+	for _, c := range []*cobra.Command{listMembersCmd, addMemberCmd, updateMemberCmd, removeMemberCmd} {
+		c.Flags().StringP("project", "p", "", "Set the project name or project ID")
+		c.Flags().StringP("group", "g", "", "Set the group path or group ID")
+	}
+
+	for _, c := range []*cobra.Command{addMemberCmd, updateMemberCmd} {
+		c.Flags().StringP("access", "a", "developer", "Set the access level: guest, reporter, developer, maintainer or owner")
+		c.Flags().StringP("expires", "e", "", "Set the membership expiry date in the YYYY-MM-DD format")
+	}
+
+	syncMembersCmd.Flags().String("from", "", "Set the source project or group")
+	cobra.MarkFlagRequired(syncMembersCmd.Flags(), "from")
+	syncMembersCmd.Flags().String("to", "", "Set the target project or group")
+	cobra.MarkFlagRequired(syncMembersCmd.Flags(), "to")
+	syncMembersCmd.Flags().Bool("dry-run", false, "Only report the changes")
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// milestoneCmd represents the milestones command
+var milestoneCmd = &cobra.Command{
+	Use:   "milestone",
+	Short: "Manage milestones",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listMilestonesCmd represents the list milestones command
+var listMilestonesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the milestones of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		state, _ := cmd.Flags().GetString("state")
+		search, _ := cmd.Flags().GetString("search")
+
+		opt := &gitlab.ListMilestonesOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}}
+		if state != "all" {
+			opt.State = gitlab.String(state)
+		}
+		if search != "" {
+			opt.Search = gitlab.String(search)
+		}
+
+		milestones, _, err := gitlabClient.Milestones.ListMilestones(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(milestones)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "TITLE", "STATE", "START DATE", "DUE DATE"})
+		for _, milestone := range milestones {
+			tw.AppendRow(table.Row{milestone.ID, milestone.Title, milestone.State, milestone.StartDate, milestone.DueDate})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// createMilestoneCmd represents the create milestone command
+var createMilestoneCmd = &cobra.Command{
+	Use:   "create TITLE",
+	Short: "Create a milestone",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		description, _ := cmd.Flags().GetString("description")
+		startDate, _ := cmd.Flags().GetString("start-date")
+		dueDate, _ := cmd.Flags().GetString("due-date")
+
+		opt := &gitlab.CreateMilestoneOptions{
+			Title:       gitlab.String(args[0]),
+			Description: gitlab.String(description),
+		}
+		if startDate != "" {
+			opt.StartDate = parseISODate(startDate)
+		}
+		if dueDate != "" {
+			opt.DueDate = parseISODate(dueDate)
+		}
+
+		milestone, _, err := gitlabClient.Milestones.CreateMilestone(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Milestone %s created\n", milestone.Title)
+	},
+}
+
+// closeMilestoneCmd represents the close milestone command
+var closeMilestoneCmd = &cobra.Command{
+	Use:   "close MILESTONE",
+	Short: "Close a milestone given its title or ID",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		milestoneID, err := strconv.Atoi(args[0])
+		if err != nil {
+			opt := &gitlab.ListMilestonesOptions{Title: gitlab.String(args[0])}
+			milestones, _, err := gitlabClient.Milestones.ListMilestones(project, opt)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(milestones) == 0 {
+				log.Fatalf("milestone %q not found", args[0])
+			}
+			milestoneID = milestones[0].ID
+		}
+
+		opt := &gitlab.UpdateMilestoneOptions{StateEvent: gitlab.String("close")}
+		milestone, _, err := gitlabClient.Milestones.UpdateMilestone(project, milestoneID, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Milestone %s closed\n", milestone.Title)
+	},
+}
+
+// parseISODate parses a date in the YYYY-MM-DD format exiting with an error if it is invalid
+func parseISODate(s string) *gitlab.ISOTime {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		log.Fatalf("invalid date %q: must be in the YYYY-MM-DD format", s)
+	}
+	isoTime := gitlab.ISOTime(t)
+	return &isoTime
+}
+
+func init() {
+	rootCmd.AddCommand(milestoneCmd)
+	milestoneCmd.AddCommand(listMilestonesCmd)
+	milestoneCmd.AddCommand(createMilestoneCmd)
+	milestoneCmd.AddCommand(closeMilestoneCmd)
+
+	milestoneCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(milestoneCmd.PersistentFlags(), "project")
+
+	listMilestonesCmd.Flags().StringP("state", "s", "active", "Filter by state: active, closed or all")
+	listMilestonesCmd.Flags().String("search", "", "Search in title and description")
+
+	createMilestoneCmd.Flags().StringP("description", "d", "", "Set the description")
+	createMilestoneCmd.Flags().String("start-date", "", "Set the start date in the YYYY-MM-DD format")
+	createMilestoneCmd.Flags().String("due-date", "", "Set the due date in the YYYY-MM-DD format")
+}
@@ -0,0 +1,486 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// mrCmd represents the merge requests command
+var mrCmd = &cobra.Command{
+	Use:   "mr",
+	Short: "Manage merge requests",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listMergeRequestsCmd represents the list merge requests command
+var listMergeRequestsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the merge requests of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		state, _ := cmd.Flags().GetString("state")
+		author, _ := cmd.Flags().GetString("author")
+		reviewer, _ := cmd.Flags().GetString("reviewer")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		targetBranch, _ := cmd.Flags().GetString("target-branch")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListProjectMergeRequestsOptions{
+			State:       gitlab.String(state),
+			ListOptions: gitlab.ListOptions{Page: 1, PerPage: limit},
+		}
+		if author != "" {
+			authorID, err := gitlabClient.GetUserID(author)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.AuthorID = gitlab.Int(authorID)
+		}
+		if len(labels) > 0 {
+			mrLabels := gitlab.Labels(labels)
+			opt.Labels = &mrLabels
+		}
+		if targetBranch != "" {
+			opt.TargetBranch = gitlab.String(targetBranch)
+		}
+
+		mergeRequests, err := gitlabClient.ListProjectMergeRequests(project, opt, reviewer)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(mergeRequests)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"IID", "TITLE", "AUTHOR", "SOURCE", "TARGET", "STATE"})
+		for _, mr := range mergeRequests {
+			tw.AppendRow(table.Row{mr.IID, mr.Title, mr.Author.Username, mr.SourceBranch, mr.TargetBranch, mr.State})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showMergeRequestCmd represents the show merge request command
+var showMergeRequestCmd = &cobra.Command{
+	Use:   "show IID",
+	Short: "Show the details of a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		mr, _, err := gitlabClient.MergeRequests.GetMergeRequest(project, iid, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(mr)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"IID:", mr.IID})
+		tw.AppendRow(table.Row{"Title:", mr.Title})
+		tw.AppendRow(table.Row{"State:", mr.State})
+		tw.AppendRow(table.Row{"Author:", mr.Author.Username})
+		if mr.Assignee != nil {
+			tw.AppendRow(table.Row{"Assignee:", mr.Assignee.Username})
+		}
+		tw.AppendRow(table.Row{"Source branch:", mr.SourceBranch})
+		tw.AppendRow(table.Row{"Target branch:", mr.TargetBranch})
+		tw.AppendRow(table.Row{"Labels:", strings.Join(mr.Labels, ", ")})
+		tw.AppendRow(table.Row{"Work in progress:", mr.WorkInProgress})
+		tw.AppendRow(table.Row{"Merge status:", mr.MergeStatus})
+		if mr.Pipeline != nil {
+			tw.AppendRow(table.Row{"Pipeline:", fmt.Sprintf("%d (%s)", mr.Pipeline.ID, mr.Pipeline.Status)})
+		}
+		tw.AppendRow(table.Row{"Created at:", mr.CreatedAt})
+		tw.AppendRow(table.Row{"Updated at:", mr.UpdatedAt})
+		tw.AppendRow(table.Row{"URL:", mr.WebURL})
+		fmt.Println(tw.Render())
+
+		if mr.Description != "" {
+			fmt.Printf("\n%s\n", mr.Description)
+		}
+	},
+}
+
+// createMergeRequestCmd represents the create merge request command
+var createMergeRequestCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a merge request from the current branch",
+	Long: `Create a merge request from the current branch
+
+The title and the description default to the subjects of the commits not yet in the target branch.
+The current branch must already be pushed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		targetBranch, _ := cmd.Flags().GetString("target-branch")
+		removeSourceBranch, _ := cmd.Flags().GetBool("remove-source-branch")
+
+		sourceBranch, err := util.CurrentBranch()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if targetBranch == "" {
+			p, _, err := gitlabClient.Projects.GetProject(project, nil)
+			if err != nil {
+				log.Fatal(err)
+			}
+			targetBranch = p.DefaultBranch
+		}
+
+		if title == "" || description == "" {
+			out, err := util.Git("log", "--reverse", "--format=%s", fmt.Sprintf("origin/%s..HEAD", targetBranch))
+			if err != nil {
+				log.Fatal(err)
+			}
+			if out == "" {
+				log.Fatalf("no commits between origin/%s and %s: nothing to merge", targetBranch, sourceBranch)
+			}
+			subjects := strings.Split(out, "\n")
+			if title == "" {
+				title = subjects[0]
+			}
+			if description == "" && len(subjects) > 1 {
+				description = "- " + strings.Join(subjects, "\n- ")
+			}
+		}
+
+		opt := &gitlab.CreateMergeRequestOptions{
+			Title:              gitlab.String(title),
+			Description:        gitlab.String(description),
+			SourceBranch:       gitlab.String(sourceBranch),
+			TargetBranch:       gitlab.String(targetBranch),
+			RemoveSourceBranch: gitlab.Bool(removeSourceBranch),
+		}
+
+		mr, _, err := gitlabClient.MergeRequests.CreateMergeRequest(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Merge request !%d created: %s\n", mr.IID, mr.WebURL)
+	},
+}
+
+// checkoutMergeRequestCmd represents the checkout merge request command
+var checkoutMergeRequestCmd = &cobra.Command{
+	Use:   "checkout IID",
+	Short: "Check out the branch of a merge request",
+	Long: `Check out the branch of a merge request
+
+The head of the merge request is fetched into the local branch mr/IID.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		remote, _ := cmd.Flags().GetString("remote")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		mr, _, err := gitlabClient.MergeRequests.GetMergeRequest(project, iid, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// the merge request head is fetched into its own branch, since a local
+		// branch named after the source branch may track something else
+		branch := fmt.Sprintf("mr/%d", mr.IID)
+		refspec := fmt.Sprintf("refs/merge-requests/%d/head:%s", mr.IID, branch)
+		if _, err := util.Git("fetch", remote, refspec); err != nil {
+			log.Fatal(err)
+		}
+		if _, err := util.Git("checkout", branch); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Switched to branch %s (%s)\n", branch, mr.SourceBranch)
+	},
+}
+
+// approveMergeRequestCmd represents the approve merge request command
+var approveMergeRequestCmd = &cobra.Command{
+	Use:   "approve IID",
+	Short: "Approve a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		_, _, err := gitlabClient.MergeRequestApprovals.ApproveMergeRequest(project, iid, &gitlab.ApproveMergeRequestOptions{})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Merge request !%d approved\n", iid)
+	},
+}
+
+// mergeMergeRequestCmd represents the merge merge request command
+var mergeMergeRequestCmd = &cobra.Command{
+	Use:   "merge IID",
+	Short: "Merge a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		whenPipelineSucceeds, _ := cmd.Flags().GetBool("when-pipeline-succeeds")
+		removeSourceBranch, _ := cmd.Flags().GetBool("remove-source-branch")
+		squash, _ := cmd.Flags().GetBool("squash")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		opt := &gitlab.AcceptMergeRequestOptions{
+			MergeWhenPipelineSucceeds: gitlab.Bool(whenPipelineSucceeds),
+			ShouldRemoveSourceBranch:  gitlab.Bool(removeSourceBranch),
+			Squash:                    gitlab.Bool(squash),
+		}
+
+		mr, _, err := gitlabClient.MergeRequests.AcceptMergeRequest(project, iid, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if whenPipelineSucceeds && mr.State != "merged" {
+			fmt.Printf("Merge request !%d will be merged when the pipeline succeeds\n", iid)
+		} else {
+			fmt.Printf("Merge request !%d merged\n", iid)
+		}
+	},
+}
+
+// closeMergeRequestCmd represents the close merge request command
+var closeMergeRequestCmd = &cobra.Command{
+	Use:   "close IID",
+	Short: "Close a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateMergeRequestState(cmd, args, "close")
+		fmt.Printf("Merge request !%s closed\n", args[0])
+	},
+}
+
+// reopenMergeRequestCmd represents the reopen merge request command
+var reopenMergeRequestCmd = &cobra.Command{
+	Use:   "reopen IID",
+	Short: "Reopen a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateMergeRequestState(cmd, args, "reopen")
+		fmt.Printf("Merge request !%s reopened\n", args[0])
+	},
+}
+
+// diffMergeRequestCmd represents the diff merge request command
+var diffMergeRequestCmd = &cobra.Command{
+	Use:   "diff IID",
+	Short: "Show the changes of a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		mr, _, err := gitlabClient.MergeRequests.GetMergeRequestChanges(project, iid)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, change := range mr.Changes {
+			printDiff(change.OldPath, change.NewPath, change.Diff)
+		}
+	},
+}
+
+// mergeRequestPipelinesCmd represents the list merge request pipelines command
+var mergeRequestPipelinesCmd = &cobra.Command{
+	Use:   "pipelines IID",
+	Short: "List the pipelines of a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		pipelines, _, err := gitlabClient.MergeRequests.ListMergeRequestPipelines(project, iid)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(pipelines)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "TYPE", "REF", "STATUS", "SHA"})
+		for _, pipeline := range pipelines {
+			tw.AppendRow(table.Row{pipeline.ID, mergeRequestPipelineType(pipeline.Ref), pipeline.Ref, sw.Sprintf(pipeline.Status), pipeline.SHA})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// mergeRequestStatusCmd represents the merge request status command
+var mergeRequestStatusCmd = &cobra.Command{
+	Use:   "status IID",
+	Short: "Display the head and merged results pipelines of a merge request",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		watch, _ := cmd.Flags().GetBool("watch")
+		iid := parseIntArg(args[0], "merge request IID")
+
+		mr, _, err := gitlabClient.MergeRequests.GetMergeRequest(project, iid, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Merge request !%d %s: %s\n", mr.IID, mr.Title, mr.MergeStatus)
+
+		if mr.HeadPipeline == nil {
+			fmt.Println("\nNo head pipeline")
+		} else {
+			fmt.Print("\nHead pipeline:\n")
+			displayPipelineStatus(gitlabClient, project, mr.HeadPipeline, watch && isPipelineActive(mr.HeadPipeline))
+		}
+
+		pipelines, _, err := gitlabClient.MergeRequests.ListMergeRequestPipelines(project, iid)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, info := range pipelines {
+			if mergeRequestPipelineType(info.Ref) != "merged results" {
+				continue
+			}
+
+			pipeline, _, err := gitlabClient.Pipelines.GetPipeline(project, info.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Print("\nMerged results pipeline:\n")
+			displayPipelineStatus(gitlabClient, project, pipeline, watch && isPipelineActive(pipeline))
+			break
+		}
+	},
+}
+
+// mergeRequestPipelineType returns the type of a merge request pipeline given its ref
+func mergeRequestPipelineType(ref string) string {
+	switch {
+	case strings.HasPrefix(ref, "refs/merge-requests/") && strings.HasSuffix(ref, "/merge"):
+		return "merged results"
+	case strings.HasPrefix(ref, "refs/merge-requests/") && strings.HasSuffix(ref, "/train"):
+		return "merge train"
+	case strings.HasPrefix(ref, "refs/merge-requests/"):
+		return "detached"
+	}
+	return "branch"
+}
+
+// updateMergeRequestState applies the state event, close or reopen, to the merge request
+func updateMergeRequestState(cmd *cobra.Command, args []string, stateEvent string) {
+	gitlabClient := client.GetClient()
+
+	project, _ := cmd.Flags().GetString("project")
+	iid := parseIntArg(args[0], "merge request IID")
+
+	opt := &gitlab.UpdateMergeRequestOptions{StateEvent: gitlab.String(stateEvent)}
+	_, _, err := gitlabClient.MergeRequests.UpdateMergeRequest(project, iid, opt)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(mrCmd)
+	mrCmd.AddCommand(listMergeRequestsCmd)
+	mrCmd.AddCommand(showMergeRequestCmd)
+	mrCmd.AddCommand(createMergeRequestCmd)
+	mrCmd.AddCommand(checkoutMergeRequestCmd)
+	mrCmd.AddCommand(approveMergeRequestCmd)
+	mrCmd.AddCommand(mergeMergeRequestCmd)
+	mrCmd.AddCommand(closeMergeRequestCmd)
+	mrCmd.AddCommand(reopenMergeRequestCmd)
+	mrCmd.AddCommand(diffMergeRequestCmd)
+	mrCmd.AddCommand(mergeRequestPipelinesCmd)
+	mrCmd.AddCommand(mergeRequestStatusCmd)
+
+	mrCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(mrCmd.PersistentFlags(), "project")
+
+	listMergeRequestsCmd.Flags().StringP("state", "s", "opened", "Filter by state: opened, closed, locked, merged or all")
+	listMergeRequestsCmd.Flags().String("author", "", "Filter by author username")
+	listMergeRequestsCmd.Flags().String("reviewer", "", "Filter by reviewer username")
+	listMergeRequestsCmd.Flags().StringSlice("labels", []string{}, "Filter by labels")
+	listMergeRequestsCmd.Flags().StringP("target-branch", "t", "", "Filter by target branch")
+	listMergeRequestsCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	createMergeRequestCmd.Flags().String("title", "", "Set the title. The default value is the subject of the first commit")
+	createMergeRequestCmd.Flags().StringP("description", "d", "", "Set the description. The default value is the list of the commit subjects")
+	createMergeRequestCmd.Flags().StringP("target-branch", "t", "", "Set the target branch. The default value is the project default branch")
+	createMergeRequestCmd.Flags().Bool("remove-source-branch", false, "Remove the source branch when merged")
+
+	mergeRequestStatusCmd.Flags().BoolP("watch", "w", false, "Watch the running pipelines execution")
+
+	checkoutMergeRequestCmd.Flags().String("remote", "origin", "Set the git remote to fetch from")
+
+	mergeMergeRequestCmd.Flags().Bool("when-pipeline-succeeds", false, "Merge when the pipeline succeeds")
+	mergeMergeRequestCmd.Flags().Bool("remove-source-branch", false, "Remove the source branch")
+	mergeMergeRequestCmd.Flags().Bool("squash", false, "Squash the commits")
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+)
+
+// packageCmd represents the package command
+var packageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "Manage the package registry of a project",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listPackagesCmd represents the list packages command
+var listPackagesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the packages",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		packageType, _ := cmd.Flags().GetString("type")
+
+		packages, err := gitlabClient.ListAllProjectPackages(project, packageType)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(packages)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "NAME", "VERSION", "TYPE", "CREATED AT"})
+		for _, pkg := range packages {
+			tw.AppendRow(table.Row{pkg.ID, pkg.Name, pkg.Version, pkg.PackageType, pkg.CreatedAt})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// deletePackageCmd represents the delete package command
+var deletePackageCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Delete a package",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		yes, _ := cmd.Flags().GetBool("yes")
+		packageID := parseIntArg(args[0], "package ID")
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete the package %d?", packageID)) {
+			log.Fatal("aborted")
+		}
+
+		if err := gitlabClient.DeleteProjectPackage(project, packageID); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Package %d deleted\n", packageID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(packageCmd)
+	packageCmd.AddCommand(listPackagesCmd)
+	packageCmd.AddCommand(deletePackageCmd)
+
+	packageCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(packageCmd.PersistentFlags(), "project")
+
+	listPackagesCmd.Flags().String("type", "", "Filter the packages by type, e.g. maven, npm or generic")
+
+	deletePackageCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
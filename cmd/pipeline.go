@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/jedib0t/go-pretty/progress"
@@ -32,6 +33,8 @@ import (
 const (
 	// WatchUpdateSleep the watch sleep
 	WatchUpdateSleep = 1000 * time.Millisecond
+	// DefaultWorkers the default number of concurrent requests of the bulk operations
+	DefaultWorkers = 5
 )
 
 // pipelineCmd represents the pipelines command
@@ -181,11 +184,15 @@ var pipelineStatusCmd = &cobra.Command{
 		pipelineID, _ := cmd.Flags().GetInt("pipeline")
 
 		pipeline, _, _ := gitlabClient.Pipelines.GetPipeline(pid, pipelineID)
-		watch := pipeline.Status == "running" || pipeline.Status == "pending"
-		displayPipelineStatus(gitlabClient, pid, pipeline, watch)
+		displayPipelineStatus(gitlabClient, pid, pipeline, isPipelineActive(pipeline))
 	},
 }
 
+// isPipelineActive returns true if the pipeline is running or pending
+func isPipelineActive(pipeline *gitlab.Pipeline) bool {
+	return pipeline.Status == "running" || pipeline.Status == "pending"
+}
+
 // runPipelineCmd represents the run pipeline command
 var runPipelineCmd = &cobra.Command{
 	Use:   "run",
@@ -228,6 +235,130 @@ var cancelPipelineCmd = &cobra.Command{
 	},
 }
 
+// retryFailedJobsCmd represents the retry failed jobs command
+var retryFailedJobsCmd = &cobra.Command{
+	Use:   "retry-failed",
+	Short: "Retry the failed jobs of a pipeline",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		runBulkJobsAction(cmd, func(job *gitlab.Job) bool {
+			return job.Status == "failed"
+		}, func(pid string, job *gitlab.Job) error {
+			_, _, err := gitlabClient.Jobs.RetryJob(pid, job.ID)
+			return err
+		})
+	},
+}
+
+// playManualJobsCmd represents the play manual jobs command
+var playManualJobsCmd = &cobra.Command{
+	Use:   "play-manual",
+	Short: "Run the manual jobs of a pipeline",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		stage, _ := cmd.Flags().GetString("stage")
+
+		runBulkJobsAction(cmd, func(job *gitlab.Job) bool {
+			return job.Status == "manual" && (stage == "" || job.Stage == stage)
+		}, func(pid string, job *gitlab.Job) error {
+			_, _, err := gitlabClient.Jobs.PlayJob(pid, job.ID)
+			return err
+		})
+	},
+}
+
+// cancelPipelineJobsCmd represents the cancel pipeline jobs command
+var cancelPipelineJobsCmd = &cobra.Command{
+	Use:   "cancel-jobs",
+	Short: "Cancel the active jobs of a pipeline",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		name, _ := cmd.Flags().GetString("name")
+		pattern, err := util.CompileGlob(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		runBulkJobsAction(cmd, func(job *gitlab.Job) bool {
+			return pattern.MatchString(job.Name) && !client.IsJobFinished(job)
+		}, func(pid string, job *gitlab.Job) error {
+			_, _, err := gitlabClient.Jobs.CancelJob(pid, job.ID)
+			return err
+		})
+	},
+}
+
+// runBulkJobsAction applies the action concurrently to the pipeline jobs
+// accepted by the filter, then prints a summary of the results.
+// The process exits with a non-zero code if any action failed.
+func runBulkJobsAction(cmd *cobra.Command, filter func(job *gitlab.Job) bool, action func(pid string, job *gitlab.Job) error) {
+	gitlabClient := client.GetClient()
+
+	pid, _ := cmd.Flags().GetString("project")
+	pipelineID, _ := cmd.Flags().GetInt("pipeline")
+	workers, _ := cmd.Flags().GetInt("workers")
+
+	jobs, err := gitlabClient.ListAllPipelineJobs(pid, pipelineID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var selected []*gitlab.Job
+	for _, job := range jobs {
+		if filter(job) {
+			selected = append(selected, job)
+		}
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("No matching jobs found")
+		return
+	}
+
+	pw := util.NewProgressWriter()
+	pw.SetAutoStop(true)
+	pw.SetUpdateFrequency(WatchUpdateSleep / 10)
+	pw.ShowValue(false)
+	tracker := &progress.Tracker{Message: fmt.Sprintf("Pipeline %d", pipelineID), Total: int64(len(selected))}
+	pw.AppendTracker(tracker)
+	go pw.Render()
+
+	errs := make([]error, len(selected))
+	util.ForEach(len(selected), workers, func(i int) {
+		errs[i] = action(pid, selected[i])
+		tracker.Increment(1)
+	})
+
+	tracker.MarkAsDone()
+	for pw.IsRenderInProgress() {
+		time.Sleep(WatchUpdateSleep / 10)
+	}
+
+	failures := 0
+	tw := util.NewTableWriter()
+	tw.AppendHeader(table.Row{"ID", "NAME", "STAGE", "RESULT"})
+	for i, job := range selected {
+		result := "ok"
+		if errs[i] != nil {
+			failures++
+			result = errs[i].Error()
+		}
+		tw.AppendRow(table.Row{job.ID, job.Name, job.Stage, result})
+	}
+	fmt.Println(tw.Render())
+
+	fmt.Printf("\n%d succeeded, %d failed\n", len(selected)-failures, failures)
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(pipelineCmd)
 	pipelineCmd.AddCommand(runPipelineCmd)
@@ -235,6 +366,9 @@ func init() {
 	pipelineCmd.AddCommand(pipelineJobsCmd)
 	pipelineCmd.AddCommand(pipelineStatusCmd)
 	pipelineCmd.AddCommand(cancelPipelineCmd)
+	pipelineCmd.AddCommand(retryFailedJobsCmd)
+	pipelineCmd.AddCommand(playManualJobsCmd)
+	pipelineCmd.AddCommand(cancelPipelineJobsCmd)
 
 	pipelineCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
 	cobra.MarkFlagRequired(pipelineCmd.PersistentFlags(), "project")
@@ -248,4 +382,13 @@ func init() {
 	cancelPipelineCmd.Flags().IntP("pipeline", "l", -1, "Set the pipeline id")
 	cobra.MarkFlagRequired(cancelPipelineCmd.Flags(), "pipeline")
 
+	for _, c := range []*cobra.Command{retryFailedJobsCmd, playManualJobsCmd, cancelPipelineJobsCmd} {
+		c.Flags().IntP("pipeline", "l", -1, "Set the pipeline id")
+		cobra.MarkFlagRequired(c.Flags(), "pipeline")
+		c.Flags().Int("workers", DefaultWorkers, "Set the number of concurrent requests")
+	}
+
+	playManualJobsCmd.Flags().StringP("stage", "s", "", "Run only the manual jobs of the given stage")
+
+	cancelPipelineJobsCmd.Flags().StringP("name", "n", "*", "Cancel only the jobs whose name matches the pattern, e.g. 'e2e:*'")
 }
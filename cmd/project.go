@@ -16,8 +16,14 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/mosteroid/gitlabctl/util"
 
@@ -25,6 +31,7 @@ import (
 	"github.com/mosteroid/gitlabctl/client"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -46,33 +53,508 @@ var listProjectsCmd = &cobra.Command{
 
 		optSearchString, _ := cmd.Flags().GetString("search")
 		optLimit, _ := cmd.Flags().GetInt("limit")
-		opt := &gitlab.ListProjectsOptions{
-			Membership:  gitlab.Bool(true),
-			Search:      gitlab.String(optSearchString),
-			ListOptions: gitlab.ListOptions{Page: 1, PerPage: optLimit}}
+		optOwned, _ := cmd.Flags().GetBool("owned")
+		optStarred, _ := cmd.Flags().GetBool("starred")
+		optGroup, _ := cmd.Flags().GetString("group")
+		optVisibility, _ := cmd.Flags().GetString("visibility")
+		optArchived, _ := cmd.Flags().GetBool("archived")
+		optLanguage, _ := cmd.Flags().GetString("language")
+		optLastActivityAfter, _ := cmd.Flags().GetString("last-activity-after")
+		optOrderBy, _ := cmd.Flags().GetString("order-by")
+		optSort, _ := cmd.Flags().GetString("sort")
+		optColumns, _ := cmd.Flags().GetStringSlice("columns")
 
-		projects, _, err := gitlabClient.Projects.ListProjects(opt)
+		var lastActivityAfter time.Time
+		if optLastActivityAfter != "" {
+			var err error
+			lastActivityAfter, err = parseTimeOrAge(optLastActivityAfter)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		var projects []*gitlab.Project
+		var err error
+		if optGroup != "" {
+			if optLanguage != "" {
+				log.Fatal("the --language flag is not supported together with --group")
+			}
+			opt := &gitlab.ListGroupProjectsOptions{
+				Search:           gitlab.String(optSearchString),
+				Owned:            gitlab.Bool(optOwned),
+				Starred:          gitlab.Bool(optStarred),
+				IncludeSubgroups: gitlab.Bool(true),
+				OrderBy:          gitlab.String(optOrderBy),
+				Sort:             gitlab.String(optSort),
+				ListOptions:      gitlab.ListOptions{Page: 1, PerPage: optLimit}}
+			if optVisibility != "" {
+				opt.Visibility = gitlab.Visibility(gitlab.VisibilityValue(optVisibility))
+			}
+			if cmd.Flags().Changed("archived") {
+				opt.Archived = gitlab.Bool(optArchived)
+			}
+			projects, err = listGroupProjectsActiveAfter(gitlabClient, optGroup, opt, lastActivityAfter, optLimit)
+		} else {
+			opt := &gitlab.ListProjectsOptions{
+				Membership:  gitlab.Bool(true),
+				Search:      gitlab.String(optSearchString),
+				Owned:       gitlab.Bool(optOwned),
+				Starred:     gitlab.Bool(optStarred),
+				OrderBy:     gitlab.String(optOrderBy),
+				Sort:        gitlab.String(optSort),
+				ListOptions: gitlab.ListOptions{Page: 1, PerPage: optLimit}}
+			if optVisibility != "" {
+				opt.Visibility = gitlab.Visibility(gitlab.VisibilityValue(optVisibility))
+			}
+			if cmd.Flags().Changed("archived") {
+				opt.Archived = gitlab.Bool(optArchived)
+			}
+			if optLanguage != "" {
+				opt.WithProgrammingLanguage = gitlab.String(optLanguage)
+			}
+			projects, err = gitlabClient.ListProjects(opt, lastActivityAfter)
+		}
 
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		if outputJSON() {
+			util.PrintJSON(projects)
+			return
+		}
+
+		header := table.Row{"ID", "Name", "Path"}
+		for _, column := range optColumns {
+			switch column {
+			case "default-branch":
+				header = append(header, "Default branch")
+			case "stars":
+				header = append(header, "Stars")
+			case "last-activity":
+				header = append(header, "Last activity")
+			case "pipeline":
+				header = append(header, "Pipeline")
+			default:
+				log.Fatalf("unknown column %q", column)
+			}
+		}
+
+		sw := util.NewStatusWriter()
 		tw := util.NewTableWriter()
-		tw.AppendHeader(table.Row{"ID", "Name", "Path"})
+		tw.AppendHeader(header)
 		for _, project := range projects {
-			tw.AppendRow(table.Row{project.ID, project.Name, project.PathWithNamespace})
+			row := table.Row{project.ID, project.Name, project.PathWithNamespace}
+			for _, column := range optColumns {
+				switch column {
+				case "default-branch":
+					row = append(row, project.DefaultBranch)
+				case "stars":
+					row = append(row, project.StarCount)
+				case "last-activity":
+					row = append(row, project.LastActivityAt)
+				case "pipeline":
+					row = append(row, sw.Sprintf(latestPipelineStatus(gitlabClient, project)))
+				}
+			}
+			tw.AppendRow(row)
+		}
+
+		fmt.Println(tw.Render())
+	},
+}
+
+// listGroupProjectsActiveAfter returns up to limit projects of a group active after the given time.
+// The group projects API does not filter by activity, so the pages are walked until the limit is reached.
+func listGroupProjectsActiveAfter(gitlabClient *client.Client, gid string, opt *gitlab.ListGroupProjectsOptions, after time.Time, limit int) ([]*gitlab.Project, error) {
+
+	if after.IsZero() {
+		projects, _, err := gitlabClient.Groups.ListGroupProjects(gid, opt)
+		return projects, err
+	}
+
+	var projects []*gitlab.Project
+	err := client.WalkPages(func(page int) (int, error) {
+		opt.Page = page
+		p, resp, err := gitlabClient.Groups.ListGroupProjects(gid, opt)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, project := range p {
+			if project.LastActivityAt != nil && project.LastActivityAt.After(after) {
+				projects = append(projects, project)
+				if len(projects) == limit {
+					return 0, nil
+				}
+			}
+		}
+		return resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// latestPipelineStatus returns the status of the latest pipeline of the project default branch
+func latestPipelineStatus(gitlabClient *client.Client, project *gitlab.Project) string {
+	opt := &gitlab.ListProjectPipelinesOptions{
+		Ref:         gitlab.String(project.DefaultBranch),
+		ListOptions: gitlab.ListOptions{Page: 1, PerPage: 1},
+	}
+	pipelines, _, err := gitlabClient.Pipelines.ListProjectPipelines(project.ID, opt)
+	if err != nil || len(pipelines) == 0 {
+		return ""
+	}
+	return pipelines[0].Status
+}
+
+// parseTimeOrAge parses either a date in the YYYY-MM-DD format or an age, e.g. 30d,
+// in which case the returned time is the current time minus the age
+func parseTimeOrAge(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	age, err := util.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: must be a date (YYYY-MM-DD) or an age (e.g. 30d)", s)
+	}
+	return time.Now().Add(-age), nil
+}
+
+// showProjectCmd represents the show project command
+var showProjectCmd = &cobra.Command{
+	Use:   "show PROJECT",
+	Short: "Show the details of a project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		opt := &gitlab.GetProjectOptions{Statistics: gitlab.Bool(true)}
+		project, _, err := gitlabClient.Projects.GetProject(args[0], opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(project)
+			return
 		}
 
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"ID:", project.ID})
+		tw.AppendRow(table.Row{"Name:", project.Name})
+		tw.AppendRow(table.Row{"Path:", project.PathWithNamespace})
+		tw.AppendRow(table.Row{"Description:", project.Description})
+		tw.AppendRow(table.Row{"Default branch:", project.DefaultBranch})
+		tw.AppendRow(table.Row{"Visibility:", project.Visibility})
+		tw.AppendRow(table.Row{"Archived:", project.Archived})
+		tw.AppendRow(table.Row{"Created at:", project.CreatedAt})
+		tw.AppendRow(table.Row{"Last activity at:", project.LastActivityAt})
+		tw.AppendRow(table.Row{"Stars:", project.StarCount})
+		tw.AppendRow(table.Row{"Forks:", project.ForksCount})
+		tw.AppendRow(table.Row{"Open issues:", project.OpenIssuesCount})
+		tw.AppendRow(table.Row{"Web URL:", project.WebURL})
+		tw.AppendRow(table.Row{"SSH URL:", project.SSHURLToRepo})
+		tw.AppendRow(table.Row{"HTTP URL:", project.HTTPURLToRepo})
 		fmt.Println(tw.Render())
+
+		fmt.Print("\nCI/CD settings:\n")
+		twCI := util.NewTableWriter()
+		twCI.AppendRow(table.Row{"Jobs enabled:", project.JobsEnabled})
+		twCI.AppendRow(table.Row{"Shared runners enabled:", project.SharedRunnersEnabled})
+		twCI.AppendRow(table.Row{"Public jobs:", project.PublicBuilds})
+		twCI.AppendRow(table.Row{"CI config path:", project.CIConfigPath})
+		twCI.AppendRow(table.Row{"Merge only if pipeline succeeds:", project.OnlyAllowMergeIfPipelineSucceeds})
+		fmt.Println(twCI.Render())
+
+		if project.Statistics != nil {
+			fmt.Print("\nStatistics:\n")
+			twStats := util.NewTableWriter()
+			twStats.AppendRow(table.Row{"Commits:", project.Statistics.CommitCount})
+			twStats.AppendRow(table.Row{"Storage:", util.FormatBytes(project.Statistics.StorageSize)})
+			twStats.AppendRow(table.Row{"Repository:", util.FormatBytes(project.Statistics.RepositorySize)})
+			twStats.AppendRow(table.Row{"LFS objects:", util.FormatBytes(project.Statistics.LfsObjectsSize)})
+			twStats.AppendRow(table.Row{"Job artifacts:", util.FormatBytes(project.Statistics.JobArtifactsSize)})
+			fmt.Println(twStats.Render())
+		}
+	},
+}
+
+// createProjectCmd represents the create project command
+var createProjectCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		path, _ := cmd.Flags().GetString("path")
+		namespace, _ := cmd.Flags().GetString("namespace")
+		description, _ := cmd.Flags().GetString("description")
+		visibility, _ := cmd.Flags().GetString("visibility")
+		template, _ := cmd.Flags().GetString("template")
+
+		opt := &gitlab.CreateProjectOptions{
+			Name:       gitlab.String(args[0]),
+			Visibility: gitlab.Visibility(gitlab.VisibilityValue(visibility)),
+		}
+		if path != "" {
+			opt.Path = gitlab.String(path)
+		}
+		if description != "" {
+			opt.Description = gitlab.String(description)
+		}
+		if template != "" {
+			opt.TemplateName = gitlab.String(template)
+		}
+		if namespace != "" {
+			ns, _, err := gitlabClient.Namespaces.GetNamespace(namespace)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.NamespaceID = gitlab.Int(ns.ID)
+		}
+
+		project, _, err := gitlabClient.Projects.CreateProject(opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Project %s created: %s\n", project.PathWithNamespace, project.WebURL)
+	},
+}
+
+// forkProjectCmd represents the fork project command
+var forkProjectCmd = &cobra.Command{
+	Use:   "fork PROJECT",
+	Short: "Fork a project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		namespace, _ := cmd.Flags().GetString("namespace")
+		name, _ := cmd.Flags().GetString("name")
+		path, _ := cmd.Flags().GetString("path")
+
+		opt := &gitlab.ForkProjectOptions{}
+		if namespace != "" {
+			opt.Namespace = gitlab.String(namespace)
+		}
+		if name != "" {
+			opt.Name = gitlab.String(name)
+		}
+		if path != "" {
+			opt.Path = gitlab.String(path)
+		}
+
+		project, _, err := gitlabClient.Projects.ForkProject(args[0], opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Project forked to %s: %s\n", project.PathWithNamespace, project.WebURL)
+	},
+}
+
+// archiveProjectCmd represents the archive project command
+var archiveProjectCmd = &cobra.Command{
+	Use:   "archive PROJECT",
+	Short: "Archive a project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _, err := gitlabClient.Projects.ArchiveProject(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Project %s archived\n", project.PathWithNamespace)
+	},
+}
+
+// unarchiveProjectCmd represents the unarchive project command
+var unarchiveProjectCmd = &cobra.Command{
+	Use:   "unarchive PROJECT",
+	Short: "Unarchive a project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _, err := gitlabClient.Projects.UnarchiveProject(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Project %s unarchived\n", project.PathWithNamespace)
 	},
 }
 
+// transferProjectCmd represents the transfer project command
+var transferProjectCmd = &cobra.Command{
+	Use:   "transfer PROJECT NAMESPACE",
+	Short: "Transfer a project to another namespace",
+	Long:  ``,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		opt := &gitlab.TransferProjectOptions{Namespace: gitlab.String(args[1])}
+		project, _, err := gitlabClient.Projects.TransferProject(args[0], opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Project transferred to %s\n", project.PathWithNamespace)
+	},
+}
+
+// deleteProjectCmd represents the delete project command
+var deleteProjectCmd = &cobra.Command{
+	Use:   "delete PROJECT",
+	Short: "Delete a project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _, err := gitlabClient.Projects.GetProject(args[0], nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !util.ConfirmName(project.PathWithNamespace) {
+			log.Fatal("aborted")
+		}
+
+		_, err = gitlabClient.Projects.DeleteProject(project.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Project %s deleted\n", project.PathWithNamespace)
+	},
+}
+
+// cloneProjectCmd represents the clone project command
+var cloneProjectCmd = &cobra.Command{
+	Use:   "clone PROJECT [DIR]",
+	Short: "Clone a project repository",
+	Long:  ``,
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		protocol := gitProtocol(cmd)
+
+		project, err := gitlabClient.ResolveProject(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		dir := project.Path
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		git := exec.Command("git", "clone", projectCloneURL(project, protocol), dir)
+		git.Stdin = os.Stdin
+		git.Stdout = os.Stdout
+		git.Stderr = os.Stderr
+		if err := git.Run(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// gitProtocol returns the protocol used to clone the repositories.
+// The --protocol flag takes precedence over the git.protocol configuration property.
+func gitProtocol(cmd *cobra.Command) string {
+	protocol, _ := cmd.Flags().GetString("protocol")
+	if !cmd.Flags().Changed("protocol") && viper.IsSet("git.protocol") {
+		protocol = viper.GetString("git.protocol")
+	}
+	if protocol != "ssh" && protocol != "https" {
+		log.Fatalf("invalid protocol %q: must be ssh or https", protocol)
+	}
+	return protocol
+}
+
+// projectCloneURL returns the repository URL of the project for the given protocol
+func projectCloneURL(project *gitlab.Project, protocol string) string {
+	if protocol == "https" {
+		return project.HTTPURLToRepo
+	}
+	return project.SSHURLToRepo
+}
+
+// errAlreadyCloned is returned by gitCloneOrUpdate when the repository is already cloned and update is false
+var errAlreadyCloned = errors.New("already cloned")
+
+// gitCloneOrUpdate clones the repository into dir, or pulls it if update is true
+// and dir already contains a clone. It returns the combined git output.
+func gitCloneOrUpdate(url, dir string, update bool) (string, error) {
+	var git *exec.Cmd
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		if !update {
+			return "", errAlreadyCloned
+		}
+		git = exec.Command("git", "-C", dir, "pull", "--ff-only")
+	} else {
+		git = exec.Command("git", "clone", url, dir)
+	}
+
+	out, err := git.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
 func init() {
 	rootCmd.AddCommand(projectCmd)
 	projectCmd.AddCommand(listProjectsCmd)
+	projectCmd.AddCommand(showProjectCmd)
+	projectCmd.AddCommand(createProjectCmd)
+	projectCmd.AddCommand(forkProjectCmd)
+	projectCmd.AddCommand(archiveProjectCmd)
+	projectCmd.AddCommand(unarchiveProjectCmd)
+	projectCmd.AddCommand(transferProjectCmd)
+	projectCmd.AddCommand(deleteProjectCmd)
+	projectCmd.AddCommand(cloneProjectCmd)
 
 	listProjectsCmd.Flags().Int("limit", 10, "Set the maximun number of results. The default value is 10")
 
 	listProjectsCmd.Flags().String("search", "", "Search a project")
+	listProjectsCmd.Flags().Bool("owned", false, "List only the projects owned by the user")
+	listProjectsCmd.Flags().Bool("starred", false, "List only the projects starred by the user")
+	listProjectsCmd.Flags().StringP("group", "g", "", "List the projects of a group and its subgroups")
+	listProjectsCmd.Flags().String("visibility", "", "Filter by visibility: private, internal or public")
+	listProjectsCmd.Flags().Bool("archived", false, "Filter by archived status")
+	listProjectsCmd.Flags().String("language", "", "Filter by programming language")
+	listProjectsCmd.Flags().String("last-activity-after", "", "List only the projects active after a date (YYYY-MM-DD) or an age (e.g. 30d)")
+	listProjectsCmd.Flags().String("order-by", "created_at", "Order by id, name, path, created_at, updated_at or last_activity_at")
+	listProjectsCmd.Flags().String("sort", "desc", "Sort in asc or desc order")
+	listProjectsCmd.Flags().StringSlice("columns", []string{}, "Show additional columns: default-branch, stars, last-activity, pipeline")
+
+	createProjectCmd.Flags().String("path", "", "Set the project path. The default value is generated from the name")
+	createProjectCmd.Flags().StringP("namespace", "n", "", "Set the namespace path or ID. The default value is the user namespace")
+	createProjectCmd.Flags().StringP("description", "d", "", "Set the project description")
+	createProjectCmd.Flags().String("visibility", "private", "Set the project visibility: private, internal or public")
+	createProjectCmd.Flags().String("template", "", "Create the project from a built-in template, e.g. rails")
+
+	forkProjectCmd.Flags().StringP("namespace", "n", "", "Set the namespace of the fork. The default value is the user namespace")
+	forkProjectCmd.Flags().String("name", "", "Set the name of the fork")
+	forkProjectCmd.Flags().String("path", "", "Set the path of the fork")
+
+	cloneProjectCmd.Flags().String("protocol", "ssh", "Set the clone protocol: ssh or https")
 
 }
@@ -0,0 +1,226 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+)
+
+// registryCmd represents the registry command
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage the container registry of a project",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listRegistryReposCmd represents the list registry repositories command
+var listRegistryReposCmd = &cobra.Command{
+	Use:   "repos",
+	Short: "List the container registry repositories",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		repositories, err := gitlabClient.ListAllRegistryRepositories(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(repositories)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "NAME", "PATH", "LOCATION", "CREATED AT"})
+		for _, repo := range repositories {
+			tw.AppendRow(table.Row{repo.ID, repo.Name, repo.Path, repo.Location, repo.CreatedAt})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// listRegistryTagsCmd represents the list registry tags command
+var listRegistryTagsCmd = &cobra.Command{
+	Use:   "tags REPO",
+	Short: "List the tags of a container registry repository",
+	Long: `List the tags of a container registry repository
+
+REPO is the ID, the path or the name of the repository.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		repo, err := gitlabClient.ResolveRegistryRepository(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tags, err := gitlabClient.ListRegistryTagDetails(project, repo.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(tags)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "REVISION", "SIZE", "CREATED AT"})
+		for _, tag := range tags {
+			tw.AppendRow(table.Row{tag.Name, tag.ShortRevision, util.FormatBytes(int64(tag.TotalSize)), tag.CreatedAt})
+		}
+		// the sizes are not summed up since the tags share their layers
+		tw.AppendFooter(table.Row{len(tags), "", "", ""})
+		fmt.Println(tw.Render())
+	},
+}
+
+// deleteRegistryTagCmd represents the delete registry tag command
+var deleteRegistryTagCmd = &cobra.Command{
+	Use:   "delete-tag REPO TAG...",
+	Short: "Delete tags of a container registry repository",
+	Long:  ``,
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		repo, err := gitlabClient.ResolveRegistryRepository(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tags := args[1:]
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete %d tags of %s?", len(tags), repo.Location)) {
+			log.Fatal("aborted")
+		}
+
+		for _, tag := range tags {
+			if _, err := gitlabClient.ContainerRegistry.DeleteRegistryRepositoryTag(project, repo.ID, tag); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Tag %s:%s deleted\n", repo.Location, tag)
+		}
+	},
+}
+
+// cleanupRegistryCmd represents the cleanup registry command
+var cleanupRegistryCmd = &cobra.Command{
+	Use:   "cleanup REPO",
+	Short: "Delete the old tags of a container registry repository",
+	Long: `Delete the old tags of a container registry repository
+
+Among the tags matching the --regex pattern, the newest --keep tags are kept and
+the remaining ones older than --older-than are deleted.
+Use --dry-run to preview the tags that would be deleted.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		keep, _ := cmd.Flags().GetInt("keep")
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		regex, _ := cmd.Flags().GetString("regex")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		age, err := util.ParseDuration(olderThan)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pattern, err := regexp.Compile("^(?:" + regex + ")$")
+		if err != nil {
+			log.Fatalf("invalid regex %q: %v", regex, err)
+		}
+
+		repo, err := gitlabClient.ResolveRegistryRepository(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tags, err := gitlabClient.ListRegistryTagDetails(project, repo.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		selected := client.SelectRegistryTagsToDelete(tags, pattern, keep, time.Now().Add(-age))
+		if len(selected) == 0 {
+			fmt.Println("No tags to delete")
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "SIZE", "CREATED AT"})
+		for _, tag := range selected {
+			tw.AppendRow(table.Row{tag.Name, util.FormatBytes(int64(tag.TotalSize)), tag.CreatedAt})
+		}
+		fmt.Println(tw.Render())
+
+		if dryRun {
+			fmt.Printf("\n%d tags would be deleted\n", len(selected))
+			return
+		}
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete %d tags of %s?", len(selected), repo.Location)) {
+			log.Fatal("aborted")
+		}
+
+		for _, tag := range selected {
+			if _, err := gitlabClient.ContainerRegistry.DeleteRegistryRepositoryTag(project, repo.ID, tag.Name); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		fmt.Printf("\n%d tags deleted, the space is freed by the next registry garbage collection\n", len(selected))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(registryCmd)
+	registryCmd.AddCommand(listRegistryReposCmd)
+	registryCmd.AddCommand(listRegistryTagsCmd)
+	registryCmd.AddCommand(deleteRegistryTagCmd)
+	registryCmd.AddCommand(cleanupRegistryCmd)
+
+	registryCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(registryCmd.PersistentFlags(), "project")
+
+	deleteRegistryTagCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+
+	cleanupRegistryCmd.Flags().Int("keep", 10, "Set the number of most recent matching tags to keep")
+	cleanupRegistryCmd.Flags().String("older-than", "30d", "Delete only the tags older than the given age, e.g. 12h, 30d or 2w")
+	cleanupRegistryCmd.Flags().String("regex", ".*", "Delete only the tags whose whole name matches the given regular expression")
+	cleanupRegistryCmd.Flags().Bool("dry-run", false, "Only report the tags that would be deleted")
+	cleanupRegistryCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
@@ -0,0 +1,269 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// releaseCmd represents the releases command
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Manage releases",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listReleasesCmd represents the list releases command
+var listReleasesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the releases of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListReleasesOptions{Page: 1, PerPage: limit}
+		releases, _, err := gitlabClient.Releases.ListReleases(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(releases)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"TAG", "NAME", "AUTHOR", "CREATED AT"})
+		for _, release := range releases {
+			tw.AppendRow(table.Row{release.TagName, release.Name, release.Author.Username, release.CreatedAt})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showReleaseCmd represents the show release command
+var showReleaseCmd = &cobra.Command{
+	Use:   "show TAG",
+	Short: "Show the details of a release",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		release, _, err := gitlabClient.Releases.GetRelease(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(release)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"Tag:", release.TagName})
+		tw.AppendRow(table.Row{"Name:", release.Name})
+		tw.AppendRow(table.Row{"Author:", release.Author.Username})
+		tw.AppendRow(table.Row{"Commit:", fmt.Sprintf("%s %s", release.Commit.ShortID, release.Commit.Title)})
+		tw.AppendRow(table.Row{"Created at:", release.CreatedAt})
+		fmt.Println(tw.Render())
+
+		if len(release.Assets.Links) > 0 {
+			fmt.Print("\nAssets:\n")
+			twAssets := util.NewTableWriter()
+			twAssets.AppendHeader(table.Row{"NAME", "URL"})
+			for _, link := range release.Assets.Links {
+				twAssets.AppendRow(table.Row{link.Name, link.URL})
+			}
+			fmt.Println(twAssets.Render())
+		}
+
+		if release.Description != "" {
+			fmt.Printf("\n%s\n", release.Description)
+		}
+	},
+}
+
+// createReleaseCmd represents the create release command
+var createReleaseCmd = &cobra.Command{
+	Use:   "create TAG",
+	Short: "Create a release",
+	Long: `Create a release
+
+The tag is created from the --ref flag when it does not exist.
+The release notes are read from the --notes-file flag or, with the --generate-notes flag,
+generated from the merge requests merged into the target branch since the previous tag.
+The previous tag is the closest one in the history of the tag, or of the --ref flag when
+the tag does not exist, unless set with the --since-tag flag.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		name, _ := cmd.Flags().GetString("name")
+		ref, _ := cmd.Flags().GetString("ref")
+		notes, _ := cmd.Flags().GetString("notes")
+		notesFile, _ := cmd.Flags().GetString("notes-file")
+		generateNotes, _ := cmd.Flags().GetBool("generate-notes")
+		targetBranch, _ := cmd.Flags().GetString("target-branch")
+		assets, _ := cmd.Flags().GetStringArray("asset")
+		milestones, _ := cmd.Flags().GetStringSlice("milestone")
+		sinceTag, _ := cmd.Flags().GetString("since-tag")
+
+		switch {
+		case notesFile != "":
+			content, err := ioutil.ReadFile(notesFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			notes = string(content)
+		case generateNotes:
+			if targetBranch == "" {
+				p, _, err := gitlabClient.Projects.GetProject(project, nil)
+				if err != nil {
+					log.Fatal(err)
+				}
+				targetBranch = p.DefaultBranch
+			}
+			previousTag, err := getPreviousTag(gitlabClient, project, args[0], ref, sinceTag)
+			if err != nil {
+				log.Fatal(err)
+			}
+			generated, err := gitlabClient.GenerateReleaseNotes(project, previousTag, targetBranch)
+			if err != nil {
+				log.Fatal(err)
+			}
+			notes = generated
+		}
+
+		if name == "" {
+			name = args[0]
+		}
+
+		opt := &gitlab.CreateReleaseOptions{
+			Name:        gitlab.String(name),
+			TagName:     gitlab.String(args[0]),
+			Description: gitlab.String(notes),
+		}
+		if ref != "" {
+			opt.Ref = gitlab.String(ref)
+		}
+		if len(assets) > 0 {
+			opt.Assets = &gitlab.ReleaseAssets{}
+			for _, asset := range assets {
+				kv := strings.SplitN(asset, "=", 2)
+				if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+					log.Fatalf("invalid asset %q: must be in the NAME=URL format", asset)
+				}
+				opt.Assets.Links = append(opt.Assets.Links, &gitlab.ReleaseAssetLink{Name: kv[0], URL: kv[1]})
+			}
+		}
+
+		release, err := gitlabClient.CreateRelease(project, opt, milestones)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Release %s created\n", release.TagName)
+	},
+}
+
+// deleteReleaseCmd represents the delete release command
+var deleteReleaseCmd = &cobra.Command{
+	Use:   "delete TAG",
+	Short: "Delete a release. The tag is kept",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete the release %s?", args[0])) {
+			log.Fatal("aborted")
+		}
+
+		_, _, err := gitlabClient.Releases.DeleteRelease(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Release %s deleted\n", args[0])
+	},
+}
+
+// getPreviousTag returns the tag set with the --since-tag flag or otherwise the tag
+// preceding the given one in its history, or in the history of the ref if the tag does not exist
+func getPreviousTag(gitlabClient *client.Client, pid, tagName, ref, sinceTag string) (*gitlab.Tag, error) {
+	if sinceTag != "" {
+		tag, _, err := gitlabClient.Tags.GetTag(pid, sinceTag)
+		return tag, err
+	}
+
+	_, resp, err := gitlabClient.Tags.GetTag(pid, tagName)
+	switch {
+	case err == nil:
+		ref = tagName
+	case resp == nil || resp.StatusCode != http.StatusNotFound:
+		return nil, err
+	case ref == "":
+		return nil, fmt.Errorf("the tag %s does not exist: requires the --ref flag", tagName)
+	}
+
+	return gitlabClient.GetPreviousTag(pid, tagName, ref)
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(listReleasesCmd)
+	releaseCmd.AddCommand(showReleaseCmd)
+	releaseCmd.AddCommand(createReleaseCmd)
+	releaseCmd.AddCommand(deleteReleaseCmd)
+
+	releaseCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(releaseCmd.PersistentFlags(), "project")
+
+	listReleasesCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	createReleaseCmd.Flags().String("name", "", "Set the release name. The default value is the tag")
+	createReleaseCmd.Flags().StringP("ref", "r", "", "Set the ref to create the tag from when it does not exist")
+	createReleaseCmd.Flags().String("notes", "", "Set the release notes")
+	createReleaseCmd.Flags().StringP("notes-file", "f", "", "Read the release notes from a file")
+	createReleaseCmd.Flags().Bool("generate-notes", false, "Generate the release notes from the merged merge requests")
+	createReleaseCmd.Flags().StringP("target-branch", "t", "", "Set the branch used to generate the release notes. The default value is the project default branch")
+	createReleaseCmd.Flags().StringArray("asset", []string{}, "Attach an asset link in the NAME=URL format. Can be repeated")
+	createReleaseCmd.Flags().StringSlice("milestone", []string{}, "Associate the release with the milestones")
+	createReleaseCmd.Flags().String("since-tag", "", "Set the tag the generated release notes start from. The default value is the previous tag")
+
+	deleteReleaseCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
@@ -0,0 +1,320 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// repoCmd represents the repository command
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Browse a project repository",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// repoTreeCmd represents the repository tree command
+var repoTreeCmd = &cobra.Command{
+	Use:   "tree [PATH]",
+	Short: "List the files of a repository directory",
+	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+
+		opt := &gitlab.ListTreeOptions{
+			Recursive:   gitlab.Bool(recursive),
+			ListOptions: gitlab.ListOptions{PerPage: 100},
+		}
+		if len(args) > 0 {
+			opt.Path = gitlab.String(args[0])
+		}
+		if ref != "" {
+			opt.Ref = gitlab.String(ref)
+		}
+
+		var nodes []*gitlab.TreeNode
+		err := client.WalkPages(func(page int) (int, error) {
+			opt.Page = page
+			p, resp, err := gitlabClient.Repositories.ListTree(project, opt)
+			if err != nil {
+				return 0, err
+			}
+			nodes = append(nodes, p...)
+			return resp.NextPage, nil
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(nodes)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"MODE", "TYPE", "PATH"})
+		for _, node := range nodes {
+			tw.AppendRow(table.Row{node.Mode, node.Type, node.Path})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// repoCatCmd represents the repository cat command
+var repoCatCmd = &cobra.Command{
+	Use:   "cat PATH",
+	Short: "Print the content of a repository file",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+
+		if ref == "" {
+			p, _, err := gitlabClient.Projects.GetProject(project, nil)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ref = p.DefaultBranch
+		}
+
+		opt := &gitlab.GetRawFileOptions{Ref: gitlab.String(ref)}
+
+		content, _, err := gitlabClient.RepositoryFiles.GetRawFile(project, args[0], opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		os.Stdout.Write(content)
+	},
+}
+
+// repoBlameCmd represents the repository blame command
+var repoBlameCmd = &cobra.Command{
+	Use:   "blame PATH",
+	Short: "Show the last commit that modified each line of a repository file",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+
+		if ref == "" {
+			p, _, err := gitlabClient.Projects.GetProject(project, nil)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ref = p.DefaultBranch
+		}
+
+		ranges, err := gitlabClient.GetFileBlame(project, args[0], ref)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(ranges)
+			return
+		}
+
+		lineNumber := 1
+		for _, r := range ranges {
+			for _, line := range r.Lines {
+				fmt.Printf("%.8s (%-20.20s %s %4d) %s\n", r.Commit.ID, r.Commit.AuthorName, r.Commit.AuthoredDate.Format("2006-01-02"), lineNumber, line)
+				lineNumber++
+			}
+		}
+	},
+}
+
+// repoCompareCmd represents the repository compare command
+var repoCompareCmd = &cobra.Command{
+	Use:   "compare FROM TO",
+	Short: "Show the commits and the changed files between two refs",
+	Long:  ``,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		showDiff, _ := cmd.Flags().GetBool("diff")
+
+		opt := &gitlab.CompareOptions{From: gitlab.String(args[0]), To: gitlab.String(args[1])}
+		compare, _, err := gitlabClient.Repositories.Compare(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(compare)
+			return
+		}
+
+		if compare.CompareTimeout {
+			fmt.Println("Warning: the comparison timed out, the results may be incomplete")
+		}
+
+		fmt.Printf("Commits (%d):\n", len(compare.Commits))
+		twCommits := util.NewTableWriter()
+		twCommits.AppendHeader(table.Row{"SHA", "AUTHOR", "DATE", "TITLE"})
+		for _, commit := range compare.Commits {
+			twCommits.AppendRow(table.Row{commit.ShortID, commit.AuthorName, commit.AuthoredDate, commit.Title})
+		}
+		fmt.Println(twCommits.Render())
+
+		fmt.Printf("\nChanged files (%d):\n", len(compare.Diffs))
+		totalAdded, totalRemoved := 0, 0
+		twDiffs := util.NewTableWriter()
+		twDiffs.AppendHeader(table.Row{"PATH", "ADDED", "REMOVED"})
+		for _, diff := range compare.Diffs {
+			added, removed := diffStat(diff.Diff)
+			totalAdded += added
+			totalRemoved += removed
+			twDiffs.AppendRow(table.Row{diffPath(diff), added, removed})
+		}
+		fmt.Println(twDiffs.Render())
+		fmt.Printf("\n%d files changed, %d insertions(+), %d deletions(-)\n", len(compare.Diffs), totalAdded, totalRemoved)
+
+		if showDiff {
+			fmt.Println()
+			for _, diff := range compare.Diffs {
+				printDiff(diff.OldPath, diff.NewPath, diff.Diff)
+			}
+		}
+	},
+}
+
+// repoArchiveCmd represents the repository archive command
+var repoArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Download an archive of a repository",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+		format, _ := cmd.Flags().GetString("format")
+		file, _ := cmd.Flags().GetString("file")
+
+		opt := &gitlab.ArchiveOptions{Format: gitlab.String(format)}
+		if ref != "" {
+			opt.SHA = gitlab.String(ref)
+		}
+
+		archive, _, err := gitlabClient.Repositories.Archive(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if file == "-" {
+			os.Stdout.Write(archive)
+			return
+		}
+
+		if file == "" {
+			name := project[strings.LastIndex(project, "/")+1:]
+			if ref != "" {
+				name = fmt.Sprintf("%s-%s", name, strings.Replace(ref, "/", "-", -1))
+			}
+			file = fmt.Sprintf("%s.%s", name, format)
+		}
+
+		if err := ioutil.WriteFile(file, archive, 0644); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Archive saved to %s (%s)\n", file, util.FormatBytes(int64(len(archive))))
+	},
+}
+
+// printDiff prints the diff of a file in the git format
+func printDiff(oldPath, newPath, diff string) {
+	fmt.Printf("diff --git a/%s b/%s\n", oldPath, newPath)
+	fmt.Printf("--- a/%s\n+++ b/%s\n", oldPath, newPath)
+	fmt.Print(diff)
+}
+
+// diffStat returns the number of added and removed lines of a diff
+func diffStat(diff string) (int, int) {
+	added, removed := 0, 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// diffPath returns the path of a changed file
+func diffPath(diff *gitlab.Diff) string {
+	switch {
+	case diff.NewFile:
+		return diff.NewPath + " (new)"
+	case diff.DeletedFile:
+		return diff.OldPath + " (deleted)"
+	case diff.RenamedFile:
+		return fmt.Sprintf("%s => %s", diff.OldPath, diff.NewPath)
+	}
+	return diff.NewPath
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+	repoCmd.AddCommand(repoTreeCmd)
+	repoCmd.AddCommand(repoCatCmd)
+	repoCmd.AddCommand(repoBlameCmd)
+	repoCmd.AddCommand(repoCompareCmd)
+	repoCmd.AddCommand(repoArchiveCmd)
+
+	repoCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(repoCmd.PersistentFlags(), "project")
+
+	for _, c := range []*cobra.Command{repoTreeCmd, repoCatCmd, repoBlameCmd, repoArchiveCmd} {
+		c.Flags().StringP("ref", "r", "", "Set the ref. The default value is the project default branch")
+	}
+
+	repoTreeCmd.Flags().BoolP("recursive", "R", false, "List the files of the subdirectories")
+
+	repoCompareCmd.Flags().Bool("diff", false, "Show also the diff of the changed files")
+
+	repoArchiveCmd.Flags().StringP("format", "f", "tar.gz", "Set the archive format: tar.gz, tar.bz2, tbz, tbz2, tb2, bz2, tar, zip")
+	repoArchiveCmd.Flags().String("file", "", "Set the archive file name, or - for the standard output")
+}
@@ -21,8 +21,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
 	"github.com/spf13/cobra"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -45,6 +47,9 @@ var rootCmd = &cobra.Command{
 		if viper.GetBool("gitlab.insecure") {
 			http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 		}
+		if err := util.ValidateOutputFormat(viper.GetString("output")); err != nil {
+			log.Fatal(err)
+		}
 		client.InitClient(viper.GetString("gitlab.baseUrl"), viper.GetString("gitlab.accessToken"))
 	},
 }
@@ -72,6 +77,35 @@ func init() {
 	rootCmd.PersistentFlags().String("accessToken", "", "Set the user access token")
 	viper.BindPFlag("gitlab.accessToken", rootCmd.PersistentFlags().Lookup("accessToken"))
 
+	rootCmd.PersistentFlags().StringP("output", "o", util.OutputTable, "Set the output format: table or json")
+	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+
+}
+
+// parseIntArg parses a numeric argument exiting with an error if it is invalid
+func parseIntArg(arg, name string) int {
+	value, err := strconv.Atoi(arg)
+	if err != nil {
+		log.Fatalf("invalid %s %q: must be a number", name, arg)
+	}
+	return value
+}
+
+// ownerFromFlags returns the project or the group selected by the --project and --group flags
+func ownerFromFlags(cmd *cobra.Command) *client.Owner {
+	project, _ := cmd.Flags().GetString("project")
+	group, _ := cmd.Flags().GetString("group")
+
+	owner := &client.Owner{Project: project, Group: group}
+	if err := owner.Validate(); err != nil {
+		log.Fatal("requires either the --project or the --group flag")
+	}
+	return owner
+}
+
+// outputJSON returns true if the results should be printed as JSON
+func outputJSON() bool {
+	return viper.GetString("output") == util.OutputJSON
 }
 
 // initConfig reads in config file and ENV variables if set.
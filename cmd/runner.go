@@ -0,0 +1,336 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// runnerLoad rapresents the load of the runners sharing a tag
+type runnerLoad struct {
+	Tag           string
+	Runners       int
+	OnlineRunners int
+	Running       int
+	Pending       int
+	MaxQueued     time.Duration
+}
+
+// runnerCmd represents the runner command
+var runnerCmd = &cobra.Command{
+	Use:   "runner",
+	Short: "Manage runners",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listRunnersCmd represents the list runners command
+var listRunnersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the runners",
+	Long: `List the runners
+
+Lists the runners of a project or a group, all the runners of the instance with --all,
+or the runners available to the user otherwise.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		all, _ := cmd.Flags().GetBool("all")
+
+		runners, err := gitlabClient.ListRunners(runnerOwnerFromFlags(cmd), all)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(runners)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "DESCRIPTION", "STATUS", "ACTIVE", "SHARED", "IP ADDRESS"})
+		for _, runner := range runners {
+			tw.AppendRow(table.Row{runner.ID, runner.Description, sw.Sprintf(runner.Status), runner.Active, runner.IsShared, runner.IPAddress})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showRunnerCmd represents the show runner command
+var showRunnerCmd = &cobra.Command{
+	Use:   "show ID",
+	Short: "Show the details of a runner",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		runnerID := parseIntArg(args[0], "runner ID")
+
+		runner, err := gitlabClient.GetRunnerDetails(runnerID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(runner)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"ID:", runner.ID})
+		tw.AppendRow(table.Row{"Description:", runner.Description})
+		tw.AppendRow(table.Row{"Status:", sw.Sprintf(runner.Status)})
+		tw.AppendRow(table.Row{"Active:", runner.Active})
+		tw.AppendRow(table.Row{"Shared:", runner.IsShared})
+		tw.AppendRow(table.Row{"Locked:", runner.Locked})
+		tw.AppendRow(table.Row{"Tags:", strings.Join(runner.TagList, ", ")})
+		tw.AppendRow(table.Row{"Run untagged:", runner.RunUntagged})
+		tw.AppendRow(table.Row{"Access level:", runner.AccessLevel})
+		tw.AppendRow(table.Row{"Maximum timeout:", util.FormatTime(int64(runner.MaximumTimeout))})
+		tw.AppendRow(table.Row{"Version:", runner.Version})
+		tw.AppendRow(table.Row{"Platform:", fmt.Sprintf("%s/%s", runner.Platform, runner.Architecture)})
+		tw.AppendRow(table.Row{"IP address:", runner.IPAddress})
+		tw.AppendRow(table.Row{"Contacted at:", runner.ContactedAt})
+		fmt.Println(tw.Render())
+
+		if len(runner.Projects) > 0 {
+			fmt.Print("\nProjects:\n")
+			twProjects := util.NewTableWriter()
+			twProjects.AppendHeader(table.Row{"ID", "PATH"})
+			for _, project := range runner.Projects {
+				twProjects.AppendRow(table.Row{project.ID, project.PathWithNamespace})
+			}
+			fmt.Println(twProjects.Render())
+		}
+	},
+}
+
+// listRunnerJobsCmd represents the list runner jobs command
+var listRunnerJobsCmd = &cobra.Command{
+	Use:   "jobs ID",
+	Short: "List the most recent jobs processed by a runner",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		status, _ := cmd.Flags().GetString("status")
+		limit, _ := cmd.Flags().GetInt("limit")
+		runnerID := parseIntArg(args[0], "runner ID")
+
+		jobs, err := gitlabClient.ListRunnerJobs(runnerID, status, limit)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(jobs)
+			return
+		}
+
+		sw := util.NewStatusWriter()
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "NAME", "REF", "STATUS", "CREATED AT", "DURATION", "URL"})
+		for _, job := range jobs {
+			tw.AppendRow(table.Row{job.ID, job.Name, job.Ref, sw.Sprintf(job.Status), job.CreatedAt, util.FormatTime(int64(job.Duration)), job.WebURL})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// pauseRunnerCmd represents the pause runner command
+var pauseRunnerCmd = &cobra.Command{
+	Use:   "pause ID",
+	Short: "Pause a runner so that it does not pick new jobs",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setRunnerActive(parseIntArg(args[0], "runner ID"), false)
+	},
+}
+
+// resumeRunnerCmd represents the resume runner command
+var resumeRunnerCmd = &cobra.Command{
+	Use:   "resume ID",
+	Short: "Resume a paused runner",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setRunnerActive(parseIntArg(args[0], "runner ID"), true)
+	},
+}
+
+// runnerLoadCmd represents the runner load command
+var runnerLoadCmd = &cobra.Command{
+	Use:   "load",
+	Short: "Summarize the running and pending jobs per runner tag",
+	Long: `Summarize the running and pending jobs per runner tag
+
+The runners are selected as in the list command. The pending jobs are counted only
+for a project or a group, since the jobs not yet picked are not bound to any runner.
+Jobs and runners without tags are reported under the ` + client.UntaggedJobs + ` tag.
+The runners whose details cannot be read are reported and skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		all, _ := cmd.Flags().GetBool("all")
+		owner := runnerOwnerFromFlags(cmd)
+
+		runners, err := gitlabClient.ListRunners(owner, all)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		details, errs := gitlabClient.ListRunnerDetails(runners)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Runner skipped, details not read: %v\n", err)
+		}
+
+		loads := make(map[string]*runnerLoad)
+		loadOf := func(tag string) *runnerLoad {
+			if loads[tag] == nil {
+				loads[tag] = &runnerLoad{Tag: tag}
+			}
+			return loads[tag]
+		}
+
+		for _, runner := range details {
+			tags := runner.TagList
+			if runner.RunUntagged {
+				tags = append(tags, client.UntaggedJobs)
+			}
+			for _, tag := range tags {
+				load := loadOf(tag)
+				load.Runners++
+				if runner.Online && runner.Active {
+					load.OnlineRunners++
+				}
+			}
+
+			running, err := gitlabClient.ListRunnerJobs(runner.ID, "running", 0)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, job := range running {
+				for _, tag := range client.JobTags(job) {
+					loadOf(tag).Running++
+				}
+			}
+		}
+
+		if owner != nil {
+			pending, err := gitlabClient.ListPendingJobs(owner)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, job := range pending {
+				queued := time.Duration(job.QueuedDuration * float64(time.Second)).Round(time.Second)
+				for _, tag := range client.JobTags(&job.Job) {
+					load := loadOf(tag)
+					load.Pending++
+					if queued > load.MaxQueued {
+						load.MaxQueued = queued
+					}
+				}
+			}
+		}
+
+		var summary []*runnerLoad
+		for _, load := range loads {
+			summary = append(summary, load)
+		}
+		sort.Slice(summary, func(i, j int) bool {
+			return summary[i].Tag < summary[j].Tag
+		})
+
+		if outputJSON() {
+			util.PrintJSON(summary)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"TAG", "ONLINE RUNNERS", "RUNNING", "PENDING", "MAX QUEUED"})
+		for _, load := range summary {
+			tw.AppendRow(table.Row{load.Tag, fmt.Sprintf("%d/%d", load.OnlineRunners, load.Runners), load.Running, load.Pending, load.MaxQueued})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// runnerOwnerFromFlags returns the project or the group selected by the --project
+// and --group flags, or nil if none is set
+func runnerOwnerFromFlags(cmd *cobra.Command) *client.Owner {
+	project, _ := cmd.Flags().GetString("project")
+	group, _ := cmd.Flags().GetString("group")
+	all, _ := cmd.Flags().GetBool("all")
+
+	if project == "" && group == "" {
+		return nil
+	}
+	if all {
+		log.Fatal("the --all flag cannot be used with the --project or the --group flag")
+	}
+	return ownerFromFlags(cmd)
+}
+
+// setRunnerActive pauses or resumes a runner
+func setRunnerActive(runnerID int, active bool) {
+	gitlabClient := client.GetClient()
+
+	_, _, err := gitlabClient.Runners.UpdateRunnerDetails(runnerID, &gitlab.UpdateRunnerDetailsOptions{Active: gitlab.Bool(active)})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if active {
+		fmt.Printf("Runner %d resumed\n", runnerID)
+	} else {
+		fmt.Printf("Runner %d paused\n", runnerID)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(runnerCmd)
+	runnerCmd.AddCommand(listRunnersCmd)
+	runnerCmd.AddCommand(showRunnerCmd)
+	runnerCmd.AddCommand(listRunnerJobsCmd)
+	runnerCmd.AddCommand(pauseRunnerCmd)
+	runnerCmd.AddCommand(resumeRunnerCmd)
+	runnerCmd.AddCommand(runnerLoadCmd)
+
+	for _, c := range []*cobra.Command{listRunnersCmd, runnerLoadCmd} {
+		c.Flags().StringP("project", "p", "", "Set the project name or project ID")
+		c.Flags().StringP("group", "g", "", "Set the group path or group ID")
+		c.Flags().Bool("all", false, "Select all the runners of the instance. Requires administrator access")
+	}
+
+	listRunnerJobsCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+	listRunnerJobsCmd.Flags().String("status", "", "Filter the jobs by status: running, success, failed or canceled")
+}
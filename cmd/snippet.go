@@ -0,0 +1,310 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+)
+
+// snippetCmd represents the snippets command
+var snippetCmd = &cobra.Command{
+	Use:   "snippet",
+	Short: "Manage personal and project snippets",
+	Long: `Manage personal and project snippets
+
+The commands act on the personal snippets unless the --project flag is set.`,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listSnippetsCmd represents the list snippets command
+var listSnippetsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the snippets",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		snippets, err := gitlabClient.ListSnippets(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(snippets)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"ID", "TITLE", "FILES", "VISIBILITY", "UPDATED AT"})
+		for _, snippet := range snippets {
+			tw.AppendRow(table.Row{snippet.ID, snippet.Title, len(snippet.Files), snippet.Visibility, snippet.UpdatedAt})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showSnippetCmd represents the show snippet command
+var showSnippetCmd = &cobra.Command{
+	Use:   "show ID",
+	Short: "Show the details of a snippet",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		snippetID := parseIntArg(args[0], "snippet ID")
+
+		snippet, err := gitlabClient.GetSnippet(project, snippetID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(snippet)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendRow(table.Row{"ID:", snippet.ID})
+		tw.AppendRow(table.Row{"Title:", snippet.Title})
+		tw.AppendRow(table.Row{"Description:", snippet.Description})
+		tw.AppendRow(table.Row{"Visibility:", snippet.Visibility})
+		tw.AppendRow(table.Row{"Author:", snippet.Author.Username})
+		tw.AppendRow(table.Row{"Created at:", snippet.CreatedAt})
+		tw.AppendRow(table.Row{"Updated at:", snippet.UpdatedAt})
+		tw.AppendRow(table.Row{"URL:", snippet.WebURL})
+		fmt.Println(tw.Render())
+
+		fmt.Print("\nFiles:\n")
+		twFiles := util.NewTableWriter()
+		twFiles.AppendHeader(table.Row{"PATH", "RAW URL"})
+		for _, file := range snippet.Files {
+			twFiles.AppendRow(table.Row{file.Path, file.RawURL})
+		}
+		fmt.Println(twFiles.Render())
+	},
+}
+
+// getSnippetCmd represents the get snippet command
+var getSnippetCmd = &cobra.Command{
+	Use:   "get ID",
+	Short: "Print the raw content of a snippet",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		snippetID := parseIntArg(args[0], "snippet ID")
+
+		content, err := gitlabClient.GetSnippetContent(project, snippetID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		os.Stdout.Write(content)
+	},
+}
+
+// createSnippetCmd represents the create snippet command
+var createSnippetCmd = &cobra.Command{
+	Use:   "create [FILE...]",
+	Short: "Create a snippet from files or from the standard input",
+	Long: `Create a snippet from files or from the standard input
+
+Every FILE becomes a file of the snippet. When no FILE is given the content is read
+from the standard input and stored in the file named by the --filename flag.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		visibility, _ := cmd.Flags().GetString("visibility")
+		filename, _ := cmd.Flags().GetString("filename")
+
+		files, err := readSnippetFiles(args, filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if title == "" {
+			title = files[0].Path
+		}
+
+		opt := &client.SnippetOptions{
+			Title:       title,
+			Description: description,
+			Visibility:  visibility,
+			Files:       files,
+		}
+
+		snippet, err := gitlabClient.CreateSnippet(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Snippet %d created: %s\n", snippet.ID, snippet.WebURL)
+	},
+}
+
+// editSnippetCmd represents the edit snippet command
+var editSnippetCmd = &cobra.Command{
+	Use:   "edit ID [FILE...]",
+	Short: "Edit a snippet",
+	Long: `Edit a snippet
+
+Every FILE is added to the snippet or replaces the snippet file with the same name.
+When no FILE and no attribute flag is given the $EDITOR is opened on the snippet content.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		visibility, _ := cmd.Flags().GetString("visibility")
+		snippetID := parseIntArg(args[0], "snippet ID")
+
+		opt := &client.SnippetOptions{
+			Title:       title,
+			Description: description,
+			Visibility:  visibility,
+		}
+
+		if len(args) > 1 {
+			files, err := readSnippetFiles(args[1:], "")
+			if err != nil {
+				log.Fatal(err)
+			}
+			opt.Files = files
+		} else if title == "" && description == "" && visibility == "" {
+			snippet, err := gitlabClient.GetSnippet(project, snippetID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(snippet.Files) != 1 {
+				log.Fatal("the snippet has more than one file: pass the files to update as arguments")
+			}
+
+			content, err := gitlabClient.GetSnippetContent(project, snippetID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			path := snippet.Files[0].Path
+			edited, err := util.EditText(string(content), "SNIPPET_*"+filepath.Ext(path))
+			if err != nil {
+				log.Fatal(err)
+			}
+			if edited == string(content) {
+				fmt.Println("Snippet unchanged")
+				return
+			}
+			opt.Files = []*client.SnippetFile{{Path: path, Content: edited}}
+		}
+
+		snippet, err := gitlabClient.UpdateSnippet(project, snippetID, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Snippet %d updated: %s\n", snippet.ID, snippet.WebURL)
+	},
+}
+
+// deleteSnippetCmd represents the delete snippet command
+var deleteSnippetCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Delete a snippet",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		yes, _ := cmd.Flags().GetBool("yes")
+		snippetID := parseIntArg(args[0], "snippet ID")
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete the snippet %d?", snippetID)) {
+			log.Fatal("aborted")
+		}
+
+		if err := gitlabClient.DeleteSnippet(project, snippetID); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Snippet %d deleted\n", snippetID)
+	},
+}
+
+// readSnippetFiles reads the given files or, if none, the standard input
+// stored in a file with the given name
+func readSnippetFiles(paths []string, stdinFilename string) ([]*client.SnippetFile, error) {
+	var files []*client.SnippetFile
+
+	if len(paths) == 0 {
+		content, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return append(files, &client.SnippetFile{Path: stdinFilename, Content: string(content)}), nil
+	}
+
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &client.SnippetFile{Path: filepath.Base(path), Content: string(content)})
+	}
+	return files, nil
+}
+
+func init() {
+	rootCmd.AddCommand(snippetCmd)
+	snippetCmd.AddCommand(listSnippetsCmd)
+	snippetCmd.AddCommand(showSnippetCmd)
+	snippetCmd.AddCommand(getSnippetCmd)
+	snippetCmd.AddCommand(createSnippetCmd)
+	snippetCmd.AddCommand(editSnippetCmd)
+	snippetCmd.AddCommand(deleteSnippetCmd)
+
+	snippetCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID of a project snippet")
+
+	createSnippetCmd.Flags().StringP("title", "t", "", "Set the title. The default value is the name of the first file")
+	createSnippetCmd.Flags().StringP("description", "d", "", "Set the description")
+	createSnippetCmd.Flags().String("visibility", "private", "Set the visibility: private, internal or public")
+	createSnippetCmd.Flags().StringP("filename", "f", "snippet.txt", "Set the file name of the content read from the standard input")
+
+	editSnippetCmd.Flags().StringP("title", "t", "", "Set the title")
+	editSnippetCmd.Flags().StringP("description", "d", "", "Set the description")
+	editSnippetCmd.Flags().String("visibility", "", "Set the visibility: private, internal or public")
+
+	deleteSnippetCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
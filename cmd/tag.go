@@ -0,0 +1,167 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// tagCmd represents the tags command
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listTagsCmd represents the list tags command
+var listTagsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the tags of a project",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		search, _ := cmd.Flags().GetString("search")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		opt := &gitlab.ListTagsOptions{
+			OrderBy:     gitlab.String("updated"),
+			Sort:        gitlab.String("desc"),
+			ListOptions: gitlab.ListOptions{Page: 1, PerPage: limit},
+		}
+
+		tags, err := gitlabClient.ListTags(project, opt, search)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(tags)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"NAME", "COMMIT", "COMMITTED AT", "MESSAGE"})
+		for _, tag := range tags {
+			tw.AppendRow(table.Row{tag.Name, tag.Commit.ShortID, tag.Commit.CommittedDate, tag.Message})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// createTagCmd represents the create tag command
+var createTagCmd = &cobra.Command{
+	Use:   "create TAG",
+	Short: "Create a tag",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		ref, _ := cmd.Flags().GetString("ref")
+		message, _ := cmd.Flags().GetString("message")
+
+		opt := &gitlab.CreateTagOptions{TagName: gitlab.String(args[0]), Ref: gitlab.String(ref)}
+		if message != "" {
+			opt.Message = gitlab.String(message)
+		}
+
+		tag, _, err := gitlabClient.Tags.CreateTag(project, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Tag %s created at %s\n", tag.Name, tag.Commit.ShortID)
+	},
+}
+
+// deleteTagCmd represents the delete tag command
+var deleteTagCmd = &cobra.Command{
+	Use:   "delete TAG",
+	Short: "Delete a tag",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		if _, err := gitlabClient.Tags.DeleteTag(project, args[0]); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Tag %s deleted\n", args[0])
+	},
+}
+
+// protectTagCmd represents the protect tag command
+var protectTagCmd = &cobra.Command{
+	Use:   "protect TAG",
+	Short: "Protect a tag or a wildcard, e.g. v*",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		create, _ := cmd.Flags().GetString("create")
+
+		createAccessLevel, err := client.ParseAccessLevel(create)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opt := &gitlab.ProtectRepositoryTagsOptions{
+			Name:              gitlab.String(args[0]),
+			CreateAccessLevel: gitlab.AccessLevel(createAccessLevel),
+		}
+		if _, _, err := gitlabClient.ProtectedTags.ProtectRepositoryTags(project, opt); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Tag %s protected: create %s\n", args[0], create)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(listTagsCmd)
+	tagCmd.AddCommand(createTagCmd)
+	tagCmd.AddCommand(deleteTagCmd)
+	tagCmd.AddCommand(protectTagCmd)
+
+	tagCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(tagCmd.PersistentFlags(), "project")
+
+	listTagsCmd.Flags().String("search", "", "Search a tag")
+	listTagsCmd.Flags().Int("limit", 20, "Set the maximun number of results. The default value is 20")
+
+	createTagCmd.Flags().StringP("ref", "r", "", "Set the ref to create the tag from")
+	cobra.MarkFlagRequired(createTagCmd.Flags(), "ref")
+	createTagCmd.Flags().StringP("message", "m", "", "Set the message to create an annotated tag")
+
+	protectTagCmd.Flags().String("create", "maintainer", "Set the access level allowed to create the tag: none, developer or maintainer")
+}
@@ -0,0 +1,277 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+)
+
+// variableCmd represents the variables command
+var variableCmd = &cobra.Command{
+	Use:   "variable",
+	Short: "Manage project and group CI/CD variables",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listVariablesCmd represents the list variables command
+var listVariablesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the CI/CD variables",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		reveal, _ := cmd.Flags().GetBool("reveal")
+
+		variables, err := gitlabClient.ListCIVariables(ownerFromFlags(cmd))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(variables)
+			return
+		}
+
+		header := table.Row{"KEY", "TYPE", "PROTECTED", "MASKED", "SCOPE"}
+		if reveal {
+			header = append(header, "VALUE")
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(header)
+		for _, variable := range variables {
+			row := table.Row{variable.Key, variable.VariableType, variable.Protected, variable.Masked, variable.EnvironmentScope}
+			if reveal {
+				row = append(row, variable.Value)
+			}
+			tw.AppendRow(row)
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// getVariableCmd represents the get variable command
+var getVariableCmd = &cobra.Command{
+	Use:   "get KEY",
+	Short: "Print the value of a CI/CD variable",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		scope, _ := cmd.Flags().GetString("scope")
+
+		variable, err := gitlabClient.GetCIVariable(ownerFromFlags(cmd), args[0], scope)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(variable)
+			return
+		}
+
+		fmt.Println(variable.Value)
+	},
+}
+
+// setVariableCmd represents the set variable command
+var setVariableCmd = &cobra.Command{
+	Use:   "set KEY [VALUE]",
+	Short: "Create or update a CI/CD variable",
+	Long: `Create or update a CI/CD variable
+
+When the VALUE is not given it is read from the standard input.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		var value string
+		if len(args) > 1 {
+			value = args[1]
+		} else {
+			stdin, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				log.Fatal(err)
+			}
+			// the trailing newline added by echo or by an editor is not part of the value
+			value = strings.TrimSuffix(string(stdin), "\n")
+		}
+
+		variable := newVariable(cmd, args[0], value)
+		if err := gitlabClient.SetCIVariable(ownerFromFlags(cmd), variable); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Variable %s set\n", variable.Key)
+	},
+}
+
+// deleteVariableCmd represents the delete variable command
+var deleteVariableCmd = &cobra.Command{
+	Use:   "delete KEY",
+	Short: "Delete a CI/CD variable",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		scope, _ := cmd.Flags().GetString("scope")
+
+		if err := gitlabClient.DeleteCIVariable(ownerFromFlags(cmd), args[0], scope); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Variable %s deleted\n", args[0])
+	},
+}
+
+// importVariablesCmd represents the import variables command
+var importVariablesCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Create or update the CI/CD variables from a file",
+	Long: `Create or update the CI/CD variables from a file
+
+The FILE is either a dotenv file or, if its extension is .json, a file produced by the export command.
+The flags apply only to the variables of a dotenv file.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		owner := ownerFromFlags(cmd)
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+
+		var variables []*client.Variable
+		if filepath.Ext(args[0]) == ".json" {
+			if err := json.NewDecoder(file).Decode(&variables); err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			entries, err := util.ParseDotenv(file)
+			if err != nil {
+				log.Fatalf("%s: %v", args[0], err)
+			}
+			for _, entry := range entries {
+				variables = append(variables, newVariable(cmd, entry.Key, entry.Value))
+			}
+		}
+
+		for _, variable := range variables {
+			if err := gitlabClient.SetCIVariable(owner, variable); err != nil {
+				log.Fatalf("%s: %v", variable.Key, err)
+			}
+			fmt.Printf("Variable %s set\n", variable.Key)
+		}
+	},
+}
+
+// exportVariablesCmd represents the export variables command
+var exportVariablesCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print the CI/CD variables in the dotenv or JSON format",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		format, _ := cmd.Flags().GetString("format")
+
+		variables, err := gitlabClient.ListCIVariables(ownerFromFlags(cmd))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		switch format {
+		case "json":
+			util.PrintJSON(variables)
+		case "dotenv":
+			var entries []*util.DotenvEntry
+			for _, variable := range variables {
+				entries = append(entries, &util.DotenvEntry{Key: variable.Key, Value: variable.Value})
+			}
+			util.WriteDotenv(os.Stdout, entries)
+		default:
+			log.Fatalf("unsupported format %q: must be dotenv or json", format)
+		}
+	},
+}
+
+// newVariable returns a variable with the attributes selected by the flags
+func newVariable(cmd *cobra.Command, key, value string) *client.Variable {
+	protected, _ := cmd.Flags().GetBool("protected")
+	masked, _ := cmd.Flags().GetBool("masked")
+	file, _ := cmd.Flags().GetBool("file")
+	scope, _ := cmd.Flags().GetString("scope")
+
+	variableType := "env_var"
+	if file {
+		variableType = "file"
+	}
+
+	return &client.Variable{
+		Key:              key,
+		Value:            value,
+		VariableType:     variableType,
+		Protected:        protected,
+		Masked:           masked,
+		EnvironmentScope: scope,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(variableCmd)
+	variableCmd.AddCommand(listVariablesCmd)
+	variableCmd.AddCommand(getVariableCmd)
+	variableCmd.AddCommand(setVariableCmd)
+	variableCmd.AddCommand(deleteVariableCmd)
+	variableCmd.AddCommand(importVariablesCmd)
+	variableCmd.AddCommand(exportVariablesCmd)
+
+	variableCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	variableCmd.PersistentFlags().StringP("group", "g", "", "Set the group path or group ID")
+
+	listVariablesCmd.Flags().Bool("reveal", false, "Show the variable values")
+
+	for _, c := range []*cobra.Command{setVariableCmd, importVariablesCmd} {
+		c.Flags().Bool("protected", false, "Expose the variable only to protected branches and tags")
+		c.Flags().Bool("masked", false, "Mask the variable in the job logs")
+		c.Flags().Bool("file", false, "Set the variable type to file")
+		c.Flags().String("scope", "*", "Set the environment scope. Supported only by project variables")
+	}
+
+	for _, c := range []*cobra.Command{getVariableCmd, deleteVariableCmd} {
+		c.Flags().String("scope", "*", "Set the environment scope. Supported only by project variables")
+	}
+
+	exportVariablesCmd.Flags().StringP("format", "f", "dotenv", "Set the export format: dotenv or json")
+}
@@ -0,0 +1,363 @@
+/*
+Copyright © 2019 The Mosteroid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mosteroid/gitlabctl/client"
+	"github.com/mosteroid/gitlabctl/util"
+	"github.com/spf13/cobra"
+	"github.com/xanzy/go-gitlab"
+)
+
+// wikiFormatExtensions maps the wiki page formats to the extensions of the exported files
+var wikiFormatExtensions = map[string]string{
+	"markdown": ".md",
+	"rdoc":     ".rdoc",
+	"asciidoc": ".adoc",
+	"org":      ".org",
+}
+
+// wikiCmd represents the wiki command
+var wikiCmd = &cobra.Command{
+	Use:   "wiki",
+	Short: "Manage the wiki pages of a project",
+	Long:  ``,
+	// Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// listWikiPagesCmd represents the list wiki pages command
+var listWikiPagesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the wiki pages",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		pages, err := gitlabClient.ListWikiPages(project, false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(pages)
+			return
+		}
+
+		tw := util.NewTableWriter()
+		tw.AppendHeader(table.Row{"SLUG", "TITLE", "FORMAT"})
+		for _, page := range pages {
+			tw.AppendRow(table.Row{page.Slug, page.Title, page.Format})
+		}
+		fmt.Println(tw.Render())
+	},
+}
+
+// showWikiPageCmd represents the show wiki page command
+var showWikiPageCmd = &cobra.Command{
+	Use:   "show SLUG",
+	Short: "Print the content of a wiki page",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+
+		page, _, err := gitlabClient.Wikis.GetWikiPage(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if outputJSON() {
+			util.PrintJSON(page)
+			return
+		}
+
+		fmt.Println(page.Content)
+	},
+}
+
+// editWikiPageCmd represents the edit wiki page command
+var editWikiPageCmd = &cobra.Command{
+	Use:   "edit SLUG",
+	Short: "Edit a wiki page with the $EDITOR",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		title, _ := cmd.Flags().GetString("title")
+
+		page, _, err := gitlabClient.Wikis.GetWikiPage(project, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		content, err := util.EditText(page.Content, "WIKI_*"+wikiFormatExtensions[string(page.Format)])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if content == page.Content && title == "" {
+			fmt.Println("Wiki page unchanged")
+			return
+		}
+
+		opt := &gitlab.EditWikiPageOptions{Content: gitlab.String(content)}
+		if title != "" {
+			opt.Title = gitlab.String(title)
+		}
+
+		page, _, err = gitlabClient.Wikis.EditWikiPage(project, page.Slug, opt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Wiki page %s updated\n", page.Slug)
+	},
+}
+
+// createWikiPageCmd represents the create wiki page command
+var createWikiPageCmd = &cobra.Command{
+	Use:   "create TITLE",
+	Short: "Create a wiki page",
+	Long: `Create a wiki page
+
+The content is read from the file set by the --file flag, otherwise the $EDITOR is opened.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+
+		var content string
+		if file != "" {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				log.Fatal(err)
+			}
+			content = string(data)
+		} else {
+			edited, err := util.EditText("", "WIKI_*"+wikiFormatExtensions[format])
+			if err != nil {
+				log.Fatal(err)
+			}
+			content = edited
+		}
+
+		if strings.TrimSpace(content) == "" {
+			log.Fatal("aborted: the content is empty")
+		}
+
+		page, _, err := gitlabClient.Wikis.CreateWikiPage(project, &gitlab.CreateWikiPageOptions{
+			Title:   gitlab.String(args[0]),
+			Content: gitlab.String(content),
+			Format:  gitlab.String(format),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Wiki page %s created\n", page.Slug)
+	},
+}
+
+// deleteWikiPageCmd represents the delete wiki page command
+var deleteWikiPageCmd = &cobra.Command{
+	Use:   "delete SLUG",
+	Short: "Delete a wiki page",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if !yes && !util.Confirm(fmt.Sprintf("Do you want to delete the wiki page %s?", args[0])) {
+			log.Fatal("aborted")
+		}
+
+		if _, err := gitlabClient.Wikis.DeleteWikiPage(project, args[0]); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Wiki page %s deleted\n", args[0])
+	},
+}
+
+// exportWikiCmd represents the export wiki command
+var exportWikiCmd = &cobra.Command{
+	Use:   "export DIR",
+	Short: "Export the wiki pages to a local directory",
+	Long: `Export the wiki pages to a local directory
+
+Every page is written to DIR/SLUG with the extension of its format, e.g. .md for markdown.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		dir := args[0]
+
+		pages, err := gitlabClient.ListWikiPages(project, true)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, page := range pages {
+			ext, ok := wikiFormatExtensions[string(page.Format)]
+			if !ok {
+				ext = ".txt"
+			}
+
+			file := filepath.Join(dir, filepath.FromSlash(page.Slug)+ext)
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				log.Fatal(err)
+			}
+			if err := ioutil.WriteFile(file, []byte(page.Content), 0644); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Exported %s\n", file)
+		}
+
+		fmt.Printf("\n%d wiki pages exported to %s\n", len(pages), dir)
+	},
+}
+
+// importWikiCmd represents the import wiki command
+var importWikiCmd = &cobra.Command{
+	Use:   "import DIR",
+	Short: "Import the wiki pages from a local directory",
+	Long: `Import the wiki pages from a local directory
+
+Every file of DIR with a wiki format extension, e.g. .md for markdown, creates or updates
+the page whose slug is the file path relative to DIR without the extension.
+Pages with unchanged content are skipped.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gitlabClient := client.GetClient()
+
+		project, _ := cmd.Flags().GetString("project")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		dir := args[0]
+
+		pages, err := gitlabClient.ListWikiPages(project, true)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		existing := make(map[string]*gitlab.Wiki)
+		for _, page := range pages {
+			existing[page.Slug] = page
+		}
+
+		formats := make(map[string]string)
+		for format, ext := range wikiFormatExtensions {
+			formats[ext] = format
+		}
+
+		created, updated := 0, 0
+		err = filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			ext := filepath.Ext(file)
+			format, ok := formats[ext]
+			if !ok {
+				return nil
+			}
+
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+			slug := filepath.ToSlash(strings.TrimSuffix(rel, ext))
+
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			content := string(data)
+
+			page, ok := existing[slug]
+			switch {
+			case !ok:
+				fmt.Printf("create %s\n", slug)
+				created++
+				if !dryRun {
+					_, _, err = gitlabClient.Wikis.CreateWikiPage(project, &gitlab.CreateWikiPageOptions{
+						Title:   gitlab.String(slug),
+						Content: gitlab.String(content),
+						Format:  gitlab.String(format),
+					})
+				}
+			case page.Content != content || string(page.Format) != format:
+				fmt.Printf("update %s\n", slug)
+				updated++
+				if !dryRun {
+					_, _, err = gitlabClient.Wikis.EditWikiPage(project, slug, &gitlab.EditWikiPageOptions{
+						Content: gitlab.String(content),
+						Format:  gitlab.String(format),
+					})
+				}
+			}
+			return err
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("\n%d wiki pages created, %d updated\n", created, updated)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(wikiCmd)
+	wikiCmd.AddCommand(listWikiPagesCmd)
+	wikiCmd.AddCommand(showWikiPageCmd)
+	wikiCmd.AddCommand(editWikiPageCmd)
+	wikiCmd.AddCommand(createWikiPageCmd)
+	wikiCmd.AddCommand(deleteWikiPageCmd)
+	wikiCmd.AddCommand(exportWikiCmd)
+	wikiCmd.AddCommand(importWikiCmd)
+
+	wikiCmd.PersistentFlags().StringP("project", "p", "", "Set the project name or project ID")
+	cobra.MarkFlagRequired(wikiCmd.PersistentFlags(), "project")
+
+	editWikiPageCmd.Flags().StringP("title", "t", "", "Set the new title")
+
+	createWikiPageCmd.Flags().StringP("file", "f", "", "Read the content from a file")
+	createWikiPageCmd.Flags().String("format", "markdown", "Set the format: markdown, rdoc, asciidoc or org")
+
+	deleteWikiPageCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+
+	importWikiCmd.Flags().Bool("dry-run", false, "Only report the changes")
+}
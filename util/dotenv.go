@@ -0,0 +1,64 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DotenvEntry rapresents a KEY=VALUE entry of a dotenv file
+type DotenvEntry struct {
+	Key   string
+	Value string
+}
+
+// ParseDotenv parses a dotenv file. Blank lines and comments are skipped,
+// the "export" prefix is allowed and double quoted values are unquoted.
+func ParseDotenv(r io.Reader) ([]*DotenvEntry, error) {
+	var entries []*DotenvEntry
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		key := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+		switch {
+		case strings.HasPrefix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted value", lineNumber)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) > 1:
+			value = value[1 : len(value)-1]
+		}
+
+		entries = append(entries, &DotenvEntry{Key: key, Value: value})
+	}
+
+	return entries, scanner.Err()
+}
+
+// WriteDotenv writes the entries in the dotenv format quoting the values
+func WriteDotenv(w io.Writer, entries []*DotenvEntry) error {
+	for _, entry := range entries {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", entry.Key, strconv.Quote(entry.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
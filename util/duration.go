@@ -0,0 +1,31 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration string. In addition to the units
+// supported by time.ParseDuration it accepts the "d" (days) and "w" (weeks) units,
+// e.g. "30d" or "2w".
+func ParseDuration(s string) (time.Duration, error) {
+
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if strings.HasSuffix(s, suffix) {
+			value, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(value) * unit, nil
+		}
+	}
+
+	return time.ParseDuration(s)
+}
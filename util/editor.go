@@ -0,0 +1,53 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// DefaultEditor the editor used when the EDITOR environment variable is not set
+const DefaultEditor = "vi"
+
+// DefaultWindowsEditor the editor used on Windows when the EDITOR environment variable is not set
+const DefaultWindowsEditor = "notepad"
+
+// EditText opens the user editor on the given text and returns the edited text
+func EditText(text, pattern string) (string, error) {
+	file, err := ioutil.TempFile("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", err
+	}
+	file.Close()
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{DefaultEditor}
+		if runtime.GOOS == "windows" {
+			editor = []string{DefaultWindowsEditor}
+		}
+	}
+
+	// the editor is run directly rather than through a shell, which is not available on Windows
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	edited, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(edited), nil
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Git runs a git command in the current directory and returns its trimmed output
+func Git(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CurrentBranch returns the name of the branch checked out in the current directory
+func CurrentBranch() (string, error) {
+	return Git("rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// RemoteURLs returns the URLs of the remotes of the repository in the current directory
+func RemoteURLs() ([]string, error) {
+	out, err := Git("config", "--get-regexp", `^remote\..*\.url$`)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			urls = append(urls, fields[1])
+		}
+	}
+	return urls, nil
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// CompileGlob compiles a glob pattern into a regular expression matching the whole text.
+// Unlike path.Match, the * matches "/" too, so that names like "rspec 1/3" match "rspec*".
+// The ? matches any single character, [...] and [!...] match a character class
+// and the backslash escapes the next character.
+func CompileGlob(pattern string) (*regexp.Regexp, error) {
+
+	var re strings.Builder
+	re.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		case '\\':
+			i++
+			if i == len(runes) {
+				return nil, errors.New("invalid glob pattern: trailing backslash")
+			}
+			re.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) || end == i+1 {
+				return nil, errors.New("invalid glob pattern: bad character class")
+			}
+			class := runes[i+1 : end]
+			re.WriteString("[")
+			if class[0] == '!' {
+				re.WriteString("^")
+				class = class[1:]
+			}
+			re.WriteString(strings.Replace(string(class), `\`, `\\`, -1))
+			re.WriteString("]")
+			i = end
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
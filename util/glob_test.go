@@ -0,0 +1,47 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*", "rspec 1/3", true},
+		{"rspec*", "rspec 1/3", true},
+		{"rspec */3", "rspec 2/3", true},
+		{"test:*", "test:unit", true},
+		{"test:*", "build", false},
+		{"test:?", "test:1", true},
+		{"test:?", "test:10", false},
+		{"job [12]/3", "job 2/3", true},
+		{"job [!12]/3", "job 2/3", false},
+		{"job [!12]/3", "job 3/3", true},
+		{`deploy\*`, "deploy*", true},
+		{`deploy\*`, "deploy prod", false},
+		{"a.b", "axb", false},
+		{"build", "build:docker", false},
+	}
+
+	for _, tt := range tests {
+		re, err := CompileGlob(tt.pattern)
+		if err != nil {
+			t.Errorf("CompileGlob(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if got := re.MatchString(tt.name); got != tt.want {
+			t.Errorf("CompileGlob(%q) matching %q = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompileGlobInvalid(t *testing.T) {
+	for _, pattern := range []string{`deploy\`, "job [12", "job []"} {
+		if _, err := CompileGlob(pattern); err == nil {
+			t.Errorf("CompileGlob(%q) returned no error", pattern)
+		}
+	}
+}
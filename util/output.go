@@ -0,0 +1,30 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	// OutputTable prints the results as human readable tables
+	OutputTable = "table"
+	// OutputJSON prints the results as JSON
+	OutputJSON = "json"
+)
+
+// PrintJSON prints the given value as indented JSON
+func PrintJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// ValidateOutputFormat returns an error if the given output format is not supported
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case OutputTable, OutputJSON:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q: must be %q or %q", format, OutputTable, OutputJSON)
+}
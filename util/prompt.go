@@ -0,0 +1,36 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Confirm asks the user a yes/no question and returns true if the answer is yes
+func Confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// ConfirmName asks the user to type the given name and returns true if it matches
+func ConfirmName(name string) bool {
+	fmt.Printf("This action cannot be undone. Type %q to confirm: ", name)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSpace(answer) == name
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"sync"
+)
+
+// ForEach calls fn for every index in [0, count) using at most workers concurrent goroutines
+func ForEach(count, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
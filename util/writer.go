@@ -20,6 +20,20 @@ func FormatTime(value int64) string {
 	return fmt.Sprintf("%ds", value)
 }
 
+// FormatBytes formats the given value as a human readable size
+func FormatBytes(value int64) string {
+	const unit = 1024
+	if value < unit {
+		return fmt.Sprintf("%dB", value)
+	}
+	div, exp := int64(unit), 0
+	for n := value / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(value)/float64(div), "KMGTPE"[exp])
+}
+
 // StatusWriter gitlab status writer
 type StatusWriter struct {
 	RunningColor            text.Color